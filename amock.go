@@ -2,11 +2,17 @@
 package amock
 
 import (
+	"errors"
+	"fmt"
+	"go/build/constraint"
+	"go/token"
+	"path/filepath"
 	"reflect"
+	"strconv"
 
+	"github.com/ymz-ncnk/amock/amockgen"
+	"github.com/ymz-ncnk/amock/amockgen/text_template"
 	"github.com/ymz-ncnk/amock/parser"
-	"github.com/ymz-ncnk/amockgen"
-	"github.com/ymz-ncnk/amockgen/text_template"
 	persistor_mod "github.com/ymz-ncnk/persistor"
 	"golang.org/x/tools/imports"
 )
@@ -17,17 +23,31 @@ const FilenameExtenstion = ".gen.go"
 // DefConf is the default configuration for AMock.
 var DefConf = Conf{Path: "testdata/mock", Package: "mock"}
 
-// New creates a new AMock.
+// New creates a new AMock, that saves the generated files to the disk with
+// AtomicPersistor.
 func New() (aMock AMock, err error) {
 	aMockGen, err := text_template.New()
 	if err != nil {
 		return
 	}
-	aMock = NewWith(aMockGen, persistor_mod.NewHarDrivePersistor())
+	aMock = NewWith(aMockGen, NewAtomicPersistor())
 	return
 }
 
-// NewWith creates a new configurable AMock.
+// NewWithManifest performs like New, but also records the generated files in
+// the ManifestFilename manifests of the output directories, so that stale ones
+// can be removed with Prune, see ManifestPersistor.
+func NewWithManifest() (aMock AMock, err error) {
+	aMockGen, err := text_template.New()
+	if err != nil {
+		return
+	}
+	aMock = NewWith(aMockGen, NewManifestPersistor(NewAtomicPersistor()))
+	return
+}
+
+// NewWith creates a new configurable AMock. The persistor may be, for
+// example, MemPersistor or WriterPersistor.
 func NewWith(aMockGen amockgen.AMockGen,
 	persistor persistor_mod.Persistor) AMock {
 	return AMock{
@@ -44,7 +64,9 @@ type AMock struct {
 
 // Generate generates mock implementation of the interface. If tp is not
 // an interface returns parser.ErrNotInterface.
-// Filename and mock implementation type will be equal to tp.Name().
+// Filename and mock implementation type will be equal to tp.Name(), for an
+// instantiated generic interface, like Repo[User], to RepoUser, see
+// parser.TypeName.
 // Uses DefConf.
 func (aMock AMock) Generate(tp reflect.Type) error {
 	return aMock.GenerateAs(tp, DefConf)
@@ -57,13 +79,137 @@ func (aMock AMock) GenerateAs(tp reflect.Type, conf Conf) (err error) {
 	if err != nil {
 		return
 	}
-	if len(conf.Package) > 0 {
-		iDesc.Package = conf.Package
+	return aMock.generate(iDesc, conf)
+}
+
+// Check performs like GenerateAs, but instead of saving the generated file,
+// compares it with the file on the disk. If they differ returns OutdatedError
+// with the unified diff.
+// To check mocks generated by the other methods, create AMock with
+// CheckPersistor.
+func (aMock AMock) Check(tp reflect.Type, conf Conf) error {
+	return NewWith(aMock.aMockGen, CheckPersistor{}).GenerateAs(tp, conf)
+}
+
+// GenerateSource generates mock implementation of the name interface declared
+// in the package, that matches the pattern, for example "./store" or
+// "github.com/user/project/store". Unlike GenerateAs, the interface is parsed
+// from the source code, so it may be generic. The generated mock type has the
+// same type parameters as the interface, i.e. StoreMock[K, V] for
+// Store[K, V].
+func (aMock AMock) GenerateSource(pattern, name string, conf Conf) (
+	err error) {
+	iDesc, err := parser.ParseSource(pattern, name)
+	if err != nil {
+		return
 	}
-	if len(conf.Name) > 0 {
-		iDesc.Name = conf.Name
+	return aMock.generate(iDesc, conf)
+}
+
+// GenerateAnnotated generates mock implementations of all interfaces annotated
+// with the parser.Directive in the packages, that match the patterns, like
+// "./...". Directive arguments configure the generation:
+//   - name - name of the mock implementation type,
+//   - file - pattern of the generated file name, see Conf.File,
+//   - constructor - name of the mock implementation constructor,
+//   - pkg - package of the mock implementation,
+//   - path - path of the generated file, relative to the interface package,
+//   - typed - if true, generates reflection-free mock,
+//   - typecheck - if true, type-checks the generated file, see Conf.TypeCheck,
+//
+// defaults are taken from DefConf. Errors of all interfaces are joined.
+func (aMock AMock) GenerateAnnotated(patterns ...string) (err error) {
+	annotations, err := parser.ParseAnnotated(patterns...)
+	if err != nil {
+		return
+	}
+	errs := []error{}
+	for _, annotation := range annotations {
+		conf, err := annotationConf(annotation)
+		if err == nil {
+			err = aMock.generate(annotation.IDesc, conf)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%v: %v: %w", annotation.Position,
+				annotation.IDesc.InterfaceType, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GenerateConfig generates mock implementations of all interfaces listed in
+// the config, see LoadConfig. Errors of all interfaces are joined.
+func (aMock AMock) GenerateConfig(config Config) (err error) {
+	errs := []error{}
+	for _, entry := range config.Mocks {
+		err = aMock.GenerateSource(entry.Pattern(), entry.Interface(), entry.Conf)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%v: %w", entry.Type, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Prune removes the generated files, that are listed in the manifests of the
+// output directories, but were not generated by this AMock, for example, after
+// the interface was renamed. The directories, in which AMock has generated
+// something, and all directories with a manifest under the roots are pruned,
+// so it should be called after a successful GenerateConfig or
+// GenerateAnnotated, that generates all mocks under the roots. Files without
+// the GeneratedHeader are never removed. Returns names of the removed files, or
+// ErrPruneUnsupported if the persistor is not a ManifestPersistor, see
+// NewWithManifest.
+func (aMock AMock) Prune(roots ...string) (removed []string, err error) {
+	persistor, ok := aMock.persistor.(*ManifestPersistor)
+	if !ok {
+		return nil, ErrPruneUnsupported
+	}
+	return persistor.Prune(roots...)
+}
+
+func annotationConf(annotation parser.Annotation) (conf Conf, err error) {
+	conf = DefConf
+	for key, value := range annotation.Args {
+		switch key {
+		case "name":
+			conf.Name = value
+		case "file":
+			conf.File = value
+		case "constructor":
+			conf.Constructor = value
+		case "pkg":
+			conf.Package = value
+		case "path":
+			conf.Path = value
+		case "typed":
+			if conf.Typed, err = strconv.ParseBool(value); err != nil {
+				return
+			}
+		case "typecheck":
+			if conf.TypeCheck, err = strconv.ParseBool(value); err != nil {
+				return
+			}
+		default:
+			err = fmt.Errorf("unknown %v argument %q", parser.Directive, key)
+			return
+		}
+	}
+	if !filepath.IsAbs(conf.Path) {
+		conf.Path = filepath.Join(annotation.Dir, conf.Path)
+	}
+	return
+}
+
+func (aMock AMock) generate(iDesc amockgen.MockImplDesc, conf Conf) (
+	err error) {
+	name, err := makeFilename(conf.File, FileData{Interface: iDesc.Name,
+		Name: mockName(iDesc.Name, conf)})
+	if err != nil {
+		return
+	}
+	if iDesc, err = configure(iDesc, conf); err != nil {
+		return
 	}
-	name := iDesc.Name + FilenameExtenstion
 	data, err := aMock.aMockGen.Generate(iDesc)
 	if err != nil {
 		return
@@ -72,5 +218,42 @@ func (aMock AMock) GenerateAs(tp reflect.Type, conf Conf) (err error) {
 	if err != nil {
 		return
 	}
+	if conf.TypeCheck {
+		if err = typeCheck(conf.Path, name, data, iDesc); err != nil {
+			return
+		}
+	}
 	return aMock.persistor.Persist(name, data, conf.Path)
 }
+
+// configure applies the conf to the iDesc.
+func configure(iDesc amockgen.MockImplDesc, conf Conf) (
+	amockgen.MockImplDesc, error) {
+	if len(conf.Package) > 0 {
+		iDesc.Package = conf.Package
+	}
+	iDesc.PackagePath = importPath(conf.Path)
+	iDesc.Name = mockName(iDesc.Name, conf)
+	iDesc.Typed = conf.Typed
+	if len(conf.BuildTags) > 0 {
+		if _, err := constraint.Parse("//go:build " + conf.BuildTags); err != nil {
+			return iDesc, err
+		}
+	}
+	if len(conf.Constructor) > 0 && !token.IsIdentifier(conf.Constructor) {
+		return iDesc, fmt.Errorf("%q constructor is not an identifier",
+			conf.Constructor)
+	}
+	iDesc.BuildTags = conf.BuildTags
+	iDesc.Header = conf.Header
+	iDesc.Constructor = conf.Constructor
+	return iDesc, nil
+}
+
+// mockName returns the name of the mock implementation type of the interface.
+func mockName(iName string, conf Conf) string {
+	if len(conf.Name) > 0 {
+		return conf.Name
+	}
+	return iName
+}
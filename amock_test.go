@@ -4,14 +4,18 @@ import (
 	"errors"
 	"io"
 	"math/big"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/ymz-ncnk/amock/amockgen"
+	"github.com/ymz-ncnk/amock/amockgen/text_template"
 	"github.com/ymz-ncnk/amock/core"
 	"github.com/ymz-ncnk/amock/parser"
 	testdata_amockgen "github.com/ymz-ncnk/amock/testdata/amockgen"
+	v1_mock "github.com/ymz-ncnk/amock/testdata/amockgen/v1/mock"
 	"github.com/ymz-ncnk/amock/testdata/mock"
-	"github.com/ymz-ncnk/amockgen"
 )
 
 func TestAmock(t *testing.T) {
@@ -23,6 +27,7 @@ func TestAmock(t *testing.T) {
 			wantIDesc = func() amockgen.MockImplDesc {
 				d := testdata_amockgen.ReaderTypeDesc
 				d.Package = wantConf.Package
+				d.PackagePath = "github.com/ymz-ncnk/amock/" + wantConf.Path
 				d.Name = wantConf.Name
 				return d
 			}()
@@ -88,6 +93,137 @@ func TestAmock(t *testing.T) {
 		}
 	})
 
+	t.Run("GenerateAnnotated", func(t *testing.T) {
+		dir, err := filepath.Abs("testdata/annotated")
+		if err != nil {
+			t.Fatal(err)
+		}
+		aMockGen, err := text_template.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		persistor := mock.NewPersistor()
+		for _, name := range []string{"StorageMock.gen.go", "Clock.gen.go"} {
+			wantName := name
+			persistor.RegisterPersist(func(name string, data []byte,
+				path string) error {
+				if name != wantName {
+					t.Errorf("unexpected name, want '%v' actual '%v'", wantName, name)
+				}
+				if wantPath := filepath.Join(dir, "testdata/mock"); path != wantPath {
+					t.Errorf("unexpected path, want '%v' actual '%v'", wantPath, path)
+				}
+				return nil
+			})
+		}
+		aMock := NewWith(aMockGen, persistor)
+		err = aMock.GenerateAnnotated("./testdata/annotated")
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := CheckCalls([]*core.Mock{persistor.Mock})
+		if len(result) > 0 {
+			t.Error(result)
+		}
+		err = aMock.GenerateAnnotated("./testdata/annotated/invalid")
+		if !errors.Is(err, parser.ErrNotInterface) {
+			t.Errorf("unexpected error '%v'", err)
+		}
+	})
+
+	t.Run("Generate instantiated generic interface", func(t *testing.T) {
+		aMockGen, err := text_template.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		persistor := mock.NewPersistor().RegisterPersist(
+			func(name string, data []byte, path string) error {
+				if want := "StoreStringItem.gen.go"; name != want {
+					t.Errorf("unexpected name, want '%v' actual '%v'", want, name)
+				}
+				for _, str := range []string{
+					"type StoreStringItem struct",
+					`"github.com/ymz-ncnk/amock/testdata/amockgen/v1/mock"`,
+					"(real amockgen.Store[string, mock.Item])",
+				} {
+					if !strings.Contains(string(data), str) {
+						t.Errorf("no '%v' in '%s'", str, data)
+					}
+				}
+				return nil
+			})
+		aMock := NewWith(aMockGen, persistor)
+		err = aMock.Generate(reflect.TypeOf(
+			(*testdata_amockgen.Store[string, v1_mock.Item])(nil)).Elem())
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := CheckCalls([]*core.Mock{persistor.Mock})
+		if len(result) > 0 {
+			t.Error(result)
+		}
+	})
+
+	t.Run("Generate into another package with the same name",
+		func(t *testing.T) {
+			aMockGen, err := text_template.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range []struct {
+				path string
+				want []string
+			}{
+				{
+					path: "testdata/mock",
+					want: []string{
+						`"github.com/ymz-ncnk/amock/testdata/amockgen/v1/mock"`,
+						"(*mock.Source)(nil)",
+						"Item() (r0 mock.Item)",
+					},
+				},
+				{
+					path: "testdata/amockgen/v1/mock",
+					want: []string{"(*Source)(nil)", "Item() (r0 Item)"},
+				},
+			} {
+				persistor := mock.NewPersistor().RegisterPersist(
+					func(name string, data []byte, path string) error {
+						for _, str := range c.want {
+							if !strings.Contains(string(data), str) {
+								t.Errorf("no '%v' in '%s'", str, data)
+							}
+						}
+						return nil
+					})
+				conf := Conf{Package: "mock", Name: "ItemSource", Path: c.path,
+					TypeCheck: true}
+				err = NewWith(aMockGen, persistor).GenerateSource(
+					"./testdata/amockgen/v1/mock", "Source", conf)
+				if err != nil {
+					t.Fatalf("unexpected error '%v'", err)
+				}
+				if result := CheckCalls([]*core.Mock{persistor.Mock}); len(result) > 0 {
+					t.Error(result)
+				}
+			}
+		})
+
+	t.Run("Generate into the interface package with the same name",
+		func(t *testing.T) {
+			aMockGen, err := text_template.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			conf := Conf{Package: "amockgen", Path: "testdata/amockgen"}
+			err = NewWith(aMockGen, mock.NewPersistor()).
+				GenerateSource("./testdata/amockgen", "Shadower", conf)
+			if !errors.Is(err, amockgen.ErrNameCollision) ||
+				!strings.Contains(err.Error(), "Shadower is already declared") {
+				t.Errorf("unexpected error '%v'", err)
+			}
+		})
+
 	t.Run("Generate for struct", func(t *testing.T) {
 		aMock, err := New()
 		if err != nil {
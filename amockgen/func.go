@@ -0,0 +1,228 @@
+package amockgen
+
+import (
+	pathpkg "path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MakeCallParams makes a list of parameters for calling a function.
+func MakeCallParams(params []VarDesc) (result string) {
+	for i := 0; i < len(params); i++ {
+		result += params[i].Name
+		if params[i].Interface || params[i].TypeParam {
+			result += "Val"
+		}
+		result += ","
+	}
+	return
+}
+
+// MakeArgs makes a list of arguments for calling a function with the same
+// params.
+func MakeArgs(params []VarDesc) (result string) {
+	for i := 0; i < len(params); i++ {
+		result += params[i].Name
+		if params[i].Variadic {
+			result += "..."
+		}
+		result += ","
+	}
+	return
+}
+
+// MakeParams makes a list of function parameters.
+func MakeParams(params []VarDesc) (result string) {
+	for i := 0; i < len(params); i++ {
+		if params[i].Variadic {
+			result += params[i].Name + " ..." +
+				strings.TrimPrefix(params[i].Type, "[]") + ","
+			continue
+		}
+		result += params[i].Name + " " + params[i].Type + ","
+	}
+	return
+}
+
+// MakeReturnVars makes a list of function return values.
+func MakeReturnVars(returnVars []VarDesc) (result string) {
+	for i := 0; i < len(returnVars); i++ {
+		result += returnVars[i].Name + " " + returnVars[i].Type + ","
+	}
+	return
+}
+
+// MakeDoc makes a comment from the doc text, like "// Line.\n". Returns an
+// empty string for an empty doc.
+func MakeDoc(doc string) (result string) {
+	doc = strings.TrimRight(doc, "\n")
+	if doc == "" {
+		return
+	}
+	for _, line := range strings.Split(doc, "\n") {
+		result += strings.TrimRight("// "+line, " ") + "\n"
+	}
+	return
+}
+
+// MakeMethodTmplData makes a data for method template. Core is the expression,
+// that refers the core mock.
+func MakeMethodTmplData(iDesc MockImplDesc, mDesc MethoDesc) struct {
+	MethoDesc    MethoDesc
+	MockImplName string
+	Core         string
+} {
+	core := "amock_m"
+	if iDesc.Core != "" {
+		core += "." + iDesc.Core
+	}
+	return struct {
+		MethoDesc    MethoDesc
+		MockImplName string
+		Core         string
+	}{
+		MethoDesc:    mDesc,
+		MockImplName: iDesc.Name + MakeTypeArgs(iDesc),
+		Core:         core,
+	}
+}
+
+// MakeConstructor returns the name of the mock implementation constructor.
+func MakeConstructor(iDesc MockImplDesc) string {
+	if iDesc.Constructor != "" {
+		return iDesc.Constructor
+	}
+	return "New" + iDesc.Name
+}
+
+// MakeInterfaceType makes the interface type as it is referred from the
+// package of the mock implementation.
+func MakeInterfaceType(iDesc MockImplDesc) string {
+	if len(iDesc.Imports) == 0 {
+		return strings.TrimPrefix(iDesc.InterfaceType, iDesc.Package+".") +
+			MakeTypeArgs(iDesc)
+	}
+	interfaceType := iDesc.InterfaceType
+	if imp, ok := LocalImport(iDesc); ok {
+		interfaceType = localQualifier(imp).ReplaceAllString(interfaceType, "")
+	}
+	return interfaceType + MakeTypeArgs(iDesc)
+}
+
+// MakeStdImports makes a list of the standard library import specs, like
+// `"io"` or `big2 "math/big"`, except the skipped paths and the local import.
+func MakeStdImports(iDesc MockImplDesc, skip ...string) (specs []string) {
+	return makeImports(iDesc, true, skip)
+}
+
+// MakeImports performs like MakeStdImports, but for the rest of imports.
+func MakeImports(iDesc MockImplDesc, skip ...string) (specs []string) {
+	return makeImports(iDesc, false, skip)
+}
+
+func makeImports(iDesc MockImplDesc, std bool, skip []string) (
+	specs []string) {
+	local, _ := LocalImport(iDesc)
+loop:
+	for _, imp := range iDesc.Imports {
+		first, _, _ := strings.Cut(imp.Path, "/")
+		if imp == local || std == strings.Contains(first, ".") {
+			continue
+		}
+		for _, path := range skip {
+			if imp.Path == path {
+				continue loop
+			}
+		}
+		spec := strconv.Quote(imp.Path)
+		if imp.Alias != pathpkg.Base(imp.Path) {
+			spec = imp.Alias + " " + spec
+		}
+		specs = append(specs, spec)
+	}
+	return
+}
+
+// LocalImport returns the import of the interface package, if the mock
+// implementation is placed into the same package, that is, the import path is
+// the PackagePath. If the PackagePath is unknown, the package with the same
+// name is considered the same.
+func LocalImport(iDesc MockImplDesc) (imp ImportDesc, ok bool) {
+	alias, _, found := strings.Cut(iDesc.InterfaceType, ".")
+	if !found {
+		return
+	}
+	for _, imp = range iDesc.Imports {
+		if imp.Alias != alias {
+			continue
+		}
+		if iDesc.PackagePath != "" && imp.Path == iDesc.PackagePath ||
+			iDesc.PackagePath == "" && imp.Name == iDesc.Package {
+			return imp, true
+		}
+	}
+	return ImportDesc{}, false
+}
+
+// Localize returns a copy of iDesc, where types of the local package, see
+// LocalImport, are not qualified.
+func Localize(iDesc MockImplDesc) MockImplDesc {
+	imp, ok := LocalImport(iDesc)
+	if !ok {
+		return iDesc
+	}
+	re := localQualifier(imp)
+	localize := func(vDescs []VarDesc) []VarDesc {
+		if vDescs == nil {
+			return nil
+		}
+		result := make([]VarDesc, len(vDescs))
+		for i, vDesc := range vDescs {
+			vDesc.Type = re.ReplaceAllString(vDesc.Type, "")
+			result[i] = vDesc
+		}
+		return result
+	}
+	methods := make([]MethoDesc, len(iDesc.Methods))
+	for i, mDesc := range iDesc.Methods {
+		mDesc.Params = localize(mDesc.Params)
+		mDesc.ReturnVars = localize(mDesc.ReturnVars)
+		methods[i] = mDesc
+	}
+	iDesc.Methods = methods
+	iDesc.TypeParams = localize(iDesc.TypeParams)
+	return iDesc
+}
+
+// localQualifier matches the qualifier of the local package types.
+func localQualifier(imp ImportDesc) *regexp.Regexp {
+	return regexp.MustCompile(`\b` + regexp.QuoteMeta(imp.Alias) + `\.`)
+}
+
+// MakeTypeParams makes a list of type parameters with constraints, like
+// "[K comparable, V any]". Returns an empty string for a non-generic
+// interface.
+func MakeTypeParams(iDesc MockImplDesc) string {
+	if len(iDesc.TypeParams) == 0 {
+		return ""
+	}
+	strs := make([]string, len(iDesc.TypeParams))
+	for i := 0; i < len(iDesc.TypeParams); i++ {
+		strs[i] = iDesc.TypeParams[i].Name + " " + iDesc.TypeParams[i].Type
+	}
+	return "[" + strings.Join(strs, ", ") + "]"
+}
+
+// MakeTypeArgs makes a list of type parameters names, like "[K, V]". Returns
+// an empty string for a non-generic interface.
+func MakeTypeArgs(iDesc MockImplDesc) string {
+	if len(iDesc.TypeParams) == 0 {
+		return ""
+	}
+	strs := make([]string, len(iDesc.TypeParams))
+	for i := 0; i < len(iDesc.TypeParams); i++ {
+		strs[i] = iDesc.TypeParams[i].Name
+	}
+	return "[" + strings.Join(strs, ", ") + "]"
+}
@@ -0,0 +1,50 @@
+package amockgen
+
+// MockImplDesc is the description of a mock implementation.
+type MockImplDesc struct {
+	InterfaceType string
+	Package       string
+	PackagePath   string // Import path of the Package, if known.
+	Name          string
+	Methods       []MethoDesc
+	Typed         bool      // If true, the reflection-free mock is generated.
+	TypeParams    []VarDesc // Type parameters of a generic interface.
+	BuildTags     string    // Build constraint expression of the file.
+	Header        string    // Comment at the top of the file.
+	Constructor   string    // Name of the constructor, "New" + Name if empty.
+	// Core is the name of the core mock field, if it can't be embedded, see
+	// Resolve.
+	Core string
+	// Imports used by the InterfaceType and types of the methods params and
+	// return variables.
+	Imports []ImportDesc
+	// PackageNames are the package level names declared by the not generated
+	// files of the interface package, if known, see Resolve.
+	PackageNames []string
+}
+
+// ImportDesc is the description of an imported package.
+type ImportDesc struct {
+	Name  string // Package name.
+	Alias string // Qualifier of the package types.
+	Path  string
+}
+
+// MethoDesc is the description of a method.
+type MethoDesc struct {
+	Name string
+	Doc  string // Doc comment of the method, without comment markers.
+	// Suffix of the helper methods names, like RegisterSuffix, see Resolve.
+	Suffix     string
+	Params     []VarDesc
+	ReturnVars []VarDesc
+}
+
+// VarDesc is the description of a variable.
+type VarDesc struct {
+	Name      string
+	Type      string
+	Interface bool
+	TypeParam bool // If true, the variable type is a type parameter.
+	Variadic  bool // If true, the param is variadic, the Type is a slice.
+}
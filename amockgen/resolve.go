@@ -0,0 +1,158 @@
+package amockgen
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ymz-ncnk/amock/core"
+)
+
+// ErrNameCollision happens when a name of the generated code collides with
+// another one, and can't be changed, see Resolve.
+var ErrNameCollision = errors.New("name collision")
+
+// helperPrefixes are the prefixes of the helper methods generated for each
+// method of the interface.
+var helperPrefixes = []string{"Register", "RegisterN", "RegisterTimes",
+	"Unregister"}
+
+// typedFieldPrefix is the prefix of the TypedMock method fields.
+const typedFieldPrefix = "method"
+
+// Resolve returns a copy of iDesc, where collisions of the generated names are
+// resolved:
+//   - if helper methods of the method collide with the other methods, like
+//     RegisterRead of the Read method and the RegisterRead method, its
+//     MethoDesc.Suffix becomes Name + "Method", Name + "Method2", ...,
+//   - if the interface methods or helper methods collide with the methods of
+//     the core mock, like Call or CheckCalls, the core mock is not embedded,
+//     but is kept in the Core field, named "Mock" ("TypedMock"), "Mock2", ...
+//
+// Names of the mock implementation type and constructors can't be changed, if
+// they collide with each other, with imported packages, or with the interface
+// and PackageNames, when the mock is placed into the interface package, returns
+// an error, that wraps ErrNameCollision.
+func Resolve(iDesc MockImplDesc) (MockImplDesc, error) {
+	if err := checkPackageNames(iDesc); err != nil {
+		return iDesc, err
+	}
+	taken := map[string]bool{}
+	for _, mDesc := range iDesc.Methods {
+		taken[mDesc.Name] = true
+	}
+	methods := make([]MethoDesc, len(iDesc.Methods))
+	for i, mDesc := range iDesc.Methods {
+		for j := 1; ; j++ {
+			mDesc.Suffix = mDesc.Name
+			if j > 1 {
+				mDesc.Suffix += "Method"
+			}
+			if j > 2 {
+				mDesc.Suffix += strconv.Itoa(j - 1)
+			}
+			if names := helperNames(iDesc, mDesc); !anyOf(taken, names) {
+				for _, name := range names {
+					taken[name] = true
+				}
+				break
+			}
+		}
+		methods[i] = mDesc
+	}
+	iDesc.Methods = methods
+	iDesc.Core = ""
+	embedded, promoted := coreNames(iDesc)
+	if taken[embedded] || anyOf(taken, promoted) {
+		iDesc.Core = embedded
+		for i := 2; taken[iDesc.Core]; i++ {
+			iDesc.Core = embedded + strconv.Itoa(i)
+		}
+	}
+	return iDesc, nil
+}
+
+// helperNames returns names of the helper methods and fields generated for the
+// method.
+func helperNames(iDesc MockImplDesc, mDesc MethoDesc) (names []string) {
+	for _, prefix := range helperPrefixes {
+		names = append(names, prefix+mDesc.Suffix)
+	}
+	if iDesc.Typed {
+		names = append(names, typedFieldPrefix+mDesc.Suffix)
+	}
+	return
+}
+
+// coreNames returns the name of the embedded core mock field and names of the
+// promoted methods.
+func coreNames(iDesc MockImplDesc) (embedded string, promoted []string) {
+	tp := reflect.TypeOf((*core.Mock)(nil))
+	if iDesc.Typed {
+		tp = reflect.TypeOf((*core.TypedMock)(nil))
+	}
+	for i := 0; i < tp.NumMethod(); i++ {
+		promoted = append(promoted, tp.Method(i).Name)
+	}
+	return tp.Elem().Name(), promoted
+}
+
+// checkPackageNames checks that the package level names of the generated code
+// don't collide with each other and with the imported packages. If the mock
+// implementation is placed into the interface package, see LocalImport, they
+// also must not collide with the interface and PackageNames.
+func checkPackageNames(iDesc MockImplDesc) error {
+	var (
+		constructor = MakeConstructor(iDesc)
+		declared    = []string{iDesc.Name, constructor, constructor + "WithT"}
+		imported    = map[string]bool{"testing": true, "amock_core": true}
+		local       = map[string]bool{}
+	)
+	if iDesc.Typed {
+		declared = append(declared, "new"+iDesc.Name)
+	} else {
+		declared = append(declared, constructor+"Wrapping")
+		imported["reflect"] = true
+	}
+	localImp, ok := LocalImport(iDesc)
+	if ok {
+		_, name, _ := strings.Cut(iDesc.InterfaceType, ".")
+		name, _, _ = strings.Cut(name, "[")
+		local[name] = true
+		for _, name := range iDesc.PackageNames {
+			local[name] = true
+		}
+	}
+	for _, imp := range iDesc.Imports {
+		if imp != localImp {
+			imported[imp.Alias] = true
+		}
+	}
+	for i, name := range declared {
+		if imported[name] {
+			return fmt.Errorf("%w: %v collides with the imported package",
+				ErrNameCollision, name)
+		}
+		if local[name] {
+			return fmt.Errorf("%w: %v is already declared in the %v package",
+				ErrNameCollision, name, iDesc.Package)
+		}
+		for _, other := range declared[:i] {
+			if name == other {
+				return fmt.Errorf("%w: %v is declared twice", ErrNameCollision, name)
+			}
+		}
+	}
+	return nil
+}
+
+func anyOf(set map[string]bool, names []string) bool {
+	for _, name := range names {
+		if set[name] {
+			return true
+		}
+	}
+	return false
+}
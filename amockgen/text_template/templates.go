@@ -0,0 +1,241 @@
+package text_template
+
+// templates holds templates of the generated code, the key is a template name.
+var templates = map[string]string{
+	"method.go.tmpl": `{{- /* MethoDesc, MockImplName */ -}}
+{{ MakeDoc .MethoDesc.Doc -}}
+func (amock_m {{.MockImplName}}) {{.MethoDesc.Name}}({{ MakeParams .MethoDesc.Params }}) ({{ MakeReturnVars .MethoDesc.ReturnVars }}) {
+	{{- range $index, $vDesc := .MethoDesc.Params }}
+		{{- if or $vDesc.Interface $vDesc.TypeParam }}
+			{{$vDesc.Name}}Val := amock_core.Wrap({{$vDesc.Name}})
+		{{- end }}
+	{{- end }}
+	{{- if eq (len .MethoDesc.ReturnVars) 0 }}
+		_, amock_err := {{.Core}}.Call("{{.MethoDesc.Name}}", {{MakeCallParams .MethoDesc.Params}})
+		if amock_err != nil {
+			{{.Core}}.Fail(amock_err)
+		}
+	{{- else }}
+		amock_result, amock_err := {{.Core}}.Call("{{.MethoDesc.Name}}", {{MakeCallParams .MethoDesc.Params}})
+		if amock_err != nil {
+			{{.Core}}.Fail(amock_err)
+			return
+		}
+		{{- range $index, $vDesc := .MethoDesc.ReturnVars }}
+			{{- if or $vDesc.Interface $vDesc.TypeParam }}
+				{{$vDesc.Name}}, _ = amock_result[{{$index}}].({{$vDesc.Type}})
+			{{- else }}
+				{{$vDesc.Name}} = amock_result[{{$index}}].({{$vDesc.Type}})
+			{{- end }}
+		{{- end }}
+		return
+	{{- end }}
+}`,
+	"mock_implementation.go.tmpl": `{{- /* MockImplDesc */ -}}
+{{- if .Header }}{{ MakeDoc .Header }}
+{{ end -}}
+// Code generated by amockgen. DO NOT EDIT.
+{{ if .BuildTags }}
+//go:build {{.BuildTags}}
+{{ end }}
+package {{.Package}}
+
+import (
+	"reflect"
+	"testing"
+	{{- range MakeStdImports . "reflect" "testing" }}
+	{{.}}
+	{{- end }}
+
+	amock_core "github.com/ymz-ncnk/amock/core"
+	{{- range MakeImports . "github.com/ymz-ncnk/amock/core" }}
+	{{.}}
+	{{- end }}
+)
+
+// {{MakeConstructor .}} creates a new {{.Name}}.
+func {{MakeConstructor .}}{{MakeTypeParams .}}() {{.Name}}{{MakeTypeArgs .}} {
+	return {{.Name}}{{MakeTypeArgs .}} {
+		{{or .Core "Mock"}}: amock_core.New("{{.Name}}").ForInterface(
+			reflect.TypeOf((*{{MakeInterfaceType .}})(nil)).Elem()),
+	}
+}
+
+// {{MakeConstructor .}}WithT creates a new {{.Name}}, which reports failures
+// to t and checks method calls at the end of the test.
+func {{MakeConstructor .}}WithT{{MakeTypeParams .}}(t testing.TB) {{.Name}}{{MakeTypeArgs .}} {
+	return {{.Name}}{{MakeTypeArgs .}} {
+		{{or .Core "Mock"}}: amock_core.NewWithT("{{.Name}}", t).ForInterface(
+			reflect.TypeOf((*{{MakeInterfaceType .}})(nil)).Elem()),
+	}
+}
+
+// {{MakeConstructor .}}Wrapping creates a new {{.Name}}, which delegates calls
+// of the unregistered methods, or calls past the registered ones, to the real
+// object.
+func {{MakeConstructor .}}Wrapping{{MakeTypeParams .}}(real {{MakeInterfaceType .}}) {{.Name}}{{MakeTypeArgs .}} {
+	return {{.Name}}{{MakeTypeArgs .}} {
+		{{or .Core "Mock"}}: amock_core.NewWrapping("{{.Name}}", real).ForInterface(
+			reflect.TypeOf((*{{MakeInterfaceType .}})(nil)).Elem()),
+	}
+}
+
+// {{.Name}} is a mock implementation of the {{.InterfaceType}}.
+type {{.Name}}{{MakeTypeParams .}} struct {
+	{{- if .Core }}
+	{{.Core}} *amock_core.Mock
+	{{- else }}
+	*amock_core.Mock
+	{{- end }}
+}
+
+{{- $iDesc := . }}
+{{- range $index, $mDesc := .Methods }}
+	{{ include "register_method.go.tmpl" (MakeMethodTmplData $iDesc $mDesc) }}
+	{{ include "register_n_method.go.tmpl" (MakeMethodTmplData $iDesc $mDesc) }}
+	{{ include "register_times_method.go.tmpl" (MakeMethodTmplData $iDesc $mDesc) }}
+	{{ include "unregister_method.go.tmpl" (MakeMethodTmplData $iDesc $mDesc) }}
+{{- end }}
+
+{{- range $index, $mDesc := .Methods }}
+	{{ include "method.go.tmpl" (MakeMethodTmplData $iDesc $mDesc) }}
+{{- end }}`,
+	"register_method.go.tmpl": `{{- /* MethoDesc, MockImplName */ -}}
+// Register{{.MethoDesc.Suffix}} registers a function as a single {{.MethoDesc.Name}}() method call.
+func (amock_m {{.MockImplName}}) Register{{.MethoDesc.Suffix}}(
+  fn func({{ MakeParams .MethoDesc.Params }}) ({{ MakeReturnVars .MethoDesc.ReturnVars }})) {{.MockImplName}} {
+  amock_core.Helper()
+  {{.Core}}.Register("{{.MethoDesc.Name}}", fn)
+  return amock_m
+}`,
+	"register_n_method.go.tmpl": `{{- /* MethoDesc, MockImplName */ -}}
+// RegisterN{{.MethoDesc.Suffix}} registers a function as n {{.MethoDesc.Name}}() method calls.
+func (amock_m {{.MockImplName}}) RegisterN{{.MethoDesc.Suffix}}(n int,
+  fn func({{ MakeParams .MethoDesc.Params }}) ({{ MakeReturnVars .MethoDesc.ReturnVars }})) {{.MockImplName}} {
+  amock_core.Helper()
+  {{.Core}}.RegisterN("{{.MethoDesc.Name}}", n, fn)
+  return amock_m
+}`,
+	"register_times_method.go.tmpl": `{{- /* MethoDesc, MockImplName */ -}}
+// RegisterTimes{{.MethoDesc.Suffix}} registers a function, which is expected to be called the specified number of times.
+func (amock_m {{.MockImplName}}) RegisterTimes{{.MethoDesc.Suffix}}(times amock_core.Times,
+  fn func({{ MakeParams .MethoDesc.Params }}) ({{ MakeReturnVars .MethoDesc.ReturnVars }})) {{.MockImplName}} {
+  amock_core.Helper()
+  {{.Core}}.RegisterTimes("{{.MethoDesc.Name}}", times, fn)
+  return amock_m
+}`,
+	"unregister_method.go.tmpl": `{{- /* MethoDesc, MockImplName */ -}}
+// Unregister{{.MethoDesc.Suffix}} unregisters {{.MethoDesc.Name}}() method calls.
+func (amock_m {{.MockImplName}}) Unregister{{.MethoDesc.Suffix}}() {{.MockImplName}} {
+  {{.Core}}.Unregister("{{.MethoDesc.Name}}")
+  return amock_m
+}`,
+	"typed_mock_implementation.go.tmpl": `{{- /* MockImplDesc */ -}}
+{{- if .Header }}{{ MakeDoc .Header }}
+{{ end -}}
+// Code generated by amockgen. DO NOT EDIT.
+{{ if .BuildTags }}
+//go:build {{.BuildTags}}
+{{ end }}
+package {{.Package}}
+
+import (
+	"testing"
+	{{- range MakeStdImports . "testing" }}
+	{{.}}
+	{{- end }}
+
+	amock_core "github.com/ymz-ncnk/amock/core"
+	{{- range MakeImports . "github.com/ymz-ncnk/amock/core" }}
+	{{.}}
+	{{- end }}
+)
+
+// {{MakeConstructor .}} creates a new {{.Name}}.
+func {{MakeConstructor .}}{{MakeTypeParams .}}() {{.Name}}{{MakeTypeArgs .}} {
+	return new{{.Name}}{{MakeTypeArgs .}}(amock_core.NewTypedMock("{{.Name}}"))
+}
+
+// {{MakeConstructor .}}WithT creates a new {{.Name}}, which reports failures
+// to t and checks method calls at the end of the test.
+func {{MakeConstructor .}}WithT{{MakeTypeParams .}}(t testing.TB) {{.Name}}{{MakeTypeArgs .}} {
+	return new{{.Name}}{{MakeTypeArgs .}}(amock_core.NewTypedMockWithT("{{.Name}}", t))
+}
+
+func new{{.Name}}{{MakeTypeParams .}}(amock_m *amock_core.TypedMock) {{.Name}}{{MakeTypeArgs .}} {
+	return {{.Name}}{{MakeTypeArgs .}} {
+		{{or .Core "TypedMock"}}: amock_m,
+		{{- range $index, $mDesc := .Methods }}
+		method{{$mDesc.Suffix}}: amock_core.AddTypedMethod[func({{ MakeParams $mDesc.Params }}) ({{ MakeReturnVars $mDesc.ReturnVars }})](amock_m, "{{$mDesc.Name}}"),
+		{{- end }}
+	}
+}
+
+// {{.Name}} is a reflection-free mock implementation of the {{.InterfaceType}}.
+type {{.Name}}{{MakeTypeParams .}} struct {
+	{{- if .Core }}
+	{{.Core}} *amock_core.TypedMock
+	{{- else }}
+	*amock_core.TypedMock
+	{{- end }}
+	{{- range $index, $mDesc := .Methods }}
+	method{{$mDesc.Suffix}} *amock_core.TypedMethod[func({{ MakeParams $mDesc.Params }}) ({{ MakeReturnVars $mDesc.ReturnVars }})]
+	{{- end }}
+}
+
+{{- $iDesc := . }}
+{{- range $index, $mDesc := .Methods }}
+	{{ include "typed_register_method.go.tmpl" (MakeMethodTmplData $iDesc $mDesc) }}
+	{{ include "typed_register_n_method.go.tmpl" (MakeMethodTmplData $iDesc $mDesc) }}
+	{{ include "typed_register_times_method.go.tmpl" (MakeMethodTmplData $iDesc $mDesc) }}
+	{{ include "typed_unregister_method.go.tmpl" (MakeMethodTmplData $iDesc $mDesc) }}
+{{- end }}
+
+{{- range $index, $mDesc := .Methods }}
+	{{ include "typed_method.go.tmpl" (MakeMethodTmplData $iDesc $mDesc) }}
+{{- end }}`,
+	"typed_register_method.go.tmpl": `{{- /* MethoDesc, MockImplName */ -}}
+// Register{{.MethoDesc.Suffix}} registers a function as a single {{.MethoDesc.Name}}() method call.
+func (amock_m {{.MockImplName}}) Register{{.MethoDesc.Suffix}}(
+  fn func({{ MakeParams .MethoDesc.Params }}) ({{ MakeReturnVars .MethoDesc.ReturnVars }})) {{.MockImplName}} {
+  amock_core.Helper()
+  amock_m.method{{.MethoDesc.Suffix}}.Register(fn)
+  return amock_m
+}`,
+	"typed_register_n_method.go.tmpl": `{{- /* MethoDesc, MockImplName */ -}}
+// RegisterN{{.MethoDesc.Suffix}} registers a function as n {{.MethoDesc.Name}}() method calls.
+func (amock_m {{.MockImplName}}) RegisterN{{.MethoDesc.Suffix}}(n int,
+  fn func({{ MakeParams .MethoDesc.Params }}) ({{ MakeReturnVars .MethoDesc.ReturnVars }})) {{.MockImplName}} {
+  amock_core.Helper()
+  amock_m.method{{.MethoDesc.Suffix}}.RegisterN(n, fn)
+  return amock_m
+}`,
+	"typed_register_times_method.go.tmpl": `{{- /* MethoDesc, MockImplName */ -}}
+// RegisterTimes{{.MethoDesc.Suffix}} registers a function, which is expected to be called the specified number of times.
+func (amock_m {{.MockImplName}}) RegisterTimes{{.MethoDesc.Suffix}}(times amock_core.Times,
+  fn func({{ MakeParams .MethoDesc.Params }}) ({{ MakeReturnVars .MethoDesc.ReturnVars }})) {{.MockImplName}} {
+  amock_core.Helper()
+  amock_m.method{{.MethoDesc.Suffix}}.RegisterTimes(times, fn)
+  return amock_m
+}`,
+	"typed_unregister_method.go.tmpl": `{{- /* MethoDesc, MockImplName */ -}}
+// Unregister{{.MethoDesc.Suffix}} unregisters {{.MethoDesc.Name}}() method calls.
+func (amock_m {{.MockImplName}}) Unregister{{.MethoDesc.Suffix}}() {{.MockImplName}} {
+  amock_m.method{{.MethoDesc.Suffix}}.Unregister()
+  return amock_m
+}`,
+	"typed_method.go.tmpl": `{{- /* MethoDesc, MockImplName */ -}}
+{{ MakeDoc .MethoDesc.Doc -}}
+func (amock_m {{.MockImplName}}) {{.MethoDesc.Name}}({{ MakeParams .MethoDesc.Params }}) ({{ MakeReturnVars .MethoDesc.ReturnVars }}) {
+	amock_fn, amock_err := amock_m.method{{.MethoDesc.Suffix}}.Next()
+	if amock_err != nil {
+		{{.Core}}.Fail(amock_err)
+		return
+	}
+	{{- if eq (len .MethoDesc.ReturnVars) 0 }}
+		amock_fn({{ MakeArgs .MethoDesc.Params }})
+	{{- else }}
+		return amock_fn({{ MakeArgs .MethoDesc.Params }})
+	{{- end }}
+}`,
+}
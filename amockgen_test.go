@@ -2,14 +2,21 @@ package amock
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
 	"os"
+	"path/filepath"
 	"reflect"
+	"runtime"
+	"strings"
 	"testing"
 
+	"github.com/ymz-ncnk/amock/amockgen"
+	"github.com/ymz-ncnk/amock/amockgen/text_template"
 	"github.com/ymz-ncnk/amock/core"
 	testdata_amockgen "github.com/ymz-ncnk/amock/testdata/amockgen"
+	v1_mock "github.com/ymz-ncnk/amock/testdata/amockgen/v1/mock"
 )
 
 func TestMxMock(t *testing.T) {
@@ -257,16 +264,19 @@ func TestUnknownMethodCall(t *testing.T) {
 }
 
 func TestUnexpectedMethodCall(t *testing.T) {
-	want := core.NewUnexpectedMethodCallError("ReaderMock", "Read")
 	reader := testdata_amockgen.NewReaderMock()
+	_, file, line, _ := runtime.Caller(0)
 	reader.RegisterRead(func(p0 []byte) (n int, err error) {
 		return 0, nil
 	})
+	want := fmt.Sprintf("unexpected ReaderMock.Read() method call\n\t"+
+		"Read() registered at %v:%v: want 1, actual 1", filepath.Base(file),
+		line+1)
 	reader.Read(nil)
 	defer func() {
 		if r := recover(); r != nil {
 			if err, ok := r.(error); ok {
-				if err.Error() != want.Error() {
+				if err.Error() != want {
 					t.Errorf("unexpected error, want '%v', actual '%v'", want, err)
 				}
 			}
@@ -274,3 +284,343 @@ func TestUnexpectedMethodCall(t *testing.T) {
 	}()
 	reader.Read(nil)
 }
+
+func TestMockWithT(t *testing.T) {
+	reader := testdata_amockgen.NewReaderMockWithT(t)
+	reader.RegisterRead(func(p0 []byte) (n int, err error) {
+		return 1, nil
+	})
+	if n, _ := reader.Read(nil); n != 1 {
+		t.Errorf("unexpected n, want '%v', actual '%v'", 1, n)
+	}
+}
+
+func TestMockTimes(t *testing.T) {
+	reader := testdata_amockgen.NewReaderMockWithT(t)
+	reader.RegisterTimesRead(core.Between(1, 3),
+		func(p0 []byte) (n int, err error) {
+			return 1, nil
+		})
+	for i := 0; i < 3; i++ {
+		reader.Read(nil)
+	}
+}
+
+func TestMockWrapping(t *testing.T) {
+	var (
+		b      = make([]byte, 3)
+		reader = testdata_amockgen.NewReaderMockWrapping(
+			strings.NewReader("abc"))
+	)
+	reader.RegisterRead(func(p0 []byte) (n int, err error) {
+		return 0, io.ErrClosedPipe
+	})
+	if _, err := reader.Read(b); err != io.ErrClosedPipe {
+		t.Errorf("unexpected err, want '%v', actual '%v'", io.ErrClosedPipe, err)
+	}
+	if n, _ := reader.Read(b); n != 3 || string(b) != "abc" {
+		t.Errorf("unexpected result n = '%v' b = '%v'", n, b)
+	}
+	if calls := reader.Calls("Read"); len(calls) != 2 || !calls[1].Delegated {
+		t.Errorf("unexpected calls '%v'", calls)
+	}
+}
+
+func TestRegisterUndefinedMethod(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(*core.UndefinedMethodError); !ok {
+			t.Error("no UndefinedMethodError panic")
+		}
+	}()
+	testdata_amockgen.NewReaderMock().Register("Raed",
+		func(p0 []byte) (n int, err error) { return })
+}
+
+func TestMxTypedMock(t *testing.T) {
+	var (
+		wantP0 = map[string]int{"str": 5}
+		wantR1 = errors.New("fail")
+	)
+	mx := testdata_amockgen.NewMxTypedMockWithT(t)
+	mx.RegisterM8(func(p0 map[string]int, p1 *io.Reader, p2 interface{}) (
+		r0 *io.WriteCloser, r1 error, r2 error) {
+		if !reflect.DeepEqual(p0, wantP0) {
+			t.Errorf("unexpected p0, want '%v', actual '%v'", wantP0, p0)
+		}
+		return nil, wantR1, nil
+	}).RegisterM10(func() {})
+	if _, r1, _ := mx.M8(wantP0, nil, nil); r1 != wantR1 {
+		t.Errorf("unexpected r1, want '%v', actual '%v'", wantR1, r1)
+	}
+	mx.M10()
+	var _ testdata_amockgen.Mx = mx
+}
+
+func TestReaderTypedMock(t *testing.T) {
+	reader := testdata_amockgen.NewReaderTypedMock()
+	reader.RegisterRead(func(p0 []byte) (n int, err error) {
+		return 0, nil
+	})
+	reader.Read(nil)
+	defer func() {
+		if _, ok := recover().(*core.UnexpectedMethodCallError); !ok {
+			t.Error("no UnexpectedMethodCallError panic")
+		}
+	}()
+	reader.Read(nil)
+}
+
+func TestCollisionMock(t *testing.T) {
+	collision := testdata_amockgen.NewCollisionMockWithT(t)
+	collision.RegisterGet(func() (r0 testdata_amockgen.Record) { return })
+	collision.Get()
+	var _ testdata_amockgen.Collision = collision
+}
+
+func TestRegistryMock(t *testing.T) {
+	registry := testdata_amockgen.NewRegistryMockWithT(t)
+	registry.RegisterRegister(func(p0 string) (r0 error) {
+		return errors.New(p0)
+	}).RegisterCall(func(p0 string, p1 ...interface{}) (r0 []interface{},
+		r1 error) {
+		return p1, nil
+	}).RegisterCheckCalls(func() (r0 int) {
+		return 1
+	}).RegisterReadMethod(func(p0 []byte) (r0 int, r1 error) {
+		return len(p0), nil
+	}).RegisterRegisterRead(func(p0 int) {}).RegisterMock(func() {})
+	if err := registry.Register("err"); err == nil || err.Error() != "err" {
+		t.Errorf("unexpected error '%v'", err)
+	}
+	if result, _ := registry.Call("Call", 1, 2); len(result) != 2 {
+		t.Errorf("unexpected result '%v'", result)
+	}
+	if n := registry.CheckCalls(); n != 1 {
+		t.Errorf("unexpected result, want '%v', actual '%v'", 1, n)
+	}
+	if n, _ := registry.Read(make([]byte, 3)); n != 3 {
+		t.Errorf("unexpected result, want '%v', actual '%v'", 3, n)
+	}
+	registry.RegisterRead(1)
+	registry.Mock()
+	registry.UnregisterUnregister()
+	if infos := registry.Mock2.CheckCalls(); len(infos) != 0 {
+		t.Errorf("unexpected infos '%v'", infos)
+	}
+	var _ testdata_amockgen.Registry = registry
+}
+
+func TestRegistryTypedMock(t *testing.T) {
+	registry := testdata_amockgen.NewRegistryTypedMockWithT(t)
+	registry.RegisterReadMethod(func(p0 []byte) (r0 int, r1 error) {
+		return len(p0), nil
+	}).RegisterUnregister(func(p0 string) {})
+	if n, _ := registry.Read(make([]byte, 2)); n != 2 {
+		t.Errorf("unexpected result, want '%v', actual '%v'", 2, n)
+	}
+	registry.Unregister("name")
+	if infos := registry.TypedMock.CheckCalls(); len(infos) != 0 {
+		t.Errorf("unexpected infos '%v'", infos)
+	}
+	var _ testdata_amockgen.Registry = registry
+}
+
+func TestEncoderMock(t *testing.T) {
+	encoder := testdata_amockgen.NewEncoderMockWithT(t)
+	encoder.RegisterNEncode(2, func(p0 reflect.Value, p1 interface{}) (
+		r0 reflect.Value, r1 error) {
+		if p1 != nil {
+			return reflect.Value{}, errors.New("unexpected p1")
+		}
+		return p0, nil
+	})
+	val, err := encoder.Encode(reflect.ValueOf(5), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val.Kind() != reflect.Int || val.Int() != 5 {
+		t.Errorf("unexpected value '%v'", val)
+	}
+	val, err = encoder.Encode(reflect.Value{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val.IsValid() {
+		t.Errorf("unexpected value '%v'", val)
+	}
+	calls := encoder.Calls("Encode")
+	if _, ok := calls[0].Args[0].(reflect.Value); !ok {
+		t.Errorf("unexpected args '%v'", calls[0].Args)
+	}
+	var _ testdata_amockgen.Encoder = encoder
+}
+
+func TestNameCollision(t *testing.T) {
+	aMockGen, err := text_template.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, iDesc := range []amockgen.MockImplDesc{
+		func() amockgen.MockImplDesc {
+			d := testdata_amockgen.ReaderTypeDesc
+			d.Name = "io"
+			return d
+		}(),
+		func() amockgen.MockImplDesc {
+			d := testdata_amockgen.ReaderTypeDesc
+			d.Constructor = "reflect"
+			return d
+		}(),
+		func() amockgen.MockImplDesc {
+			d := testdata_amockgen.ReaderTypedTypeDesc
+			d.Constructor = d.Name
+			return d
+		}(),
+		// The mock is placed into the interface package.
+		func() amockgen.MockImplDesc {
+			d := testdata_amockgen.StoreTypeDesc
+			d.Name = "Store"
+			return d
+		}(),
+		func() amockgen.MockImplDesc {
+			d := testdata_amockgen.StoreTypeDesc
+			d.PackageNames = []string{"Record", "NewStoreMock"}
+			return d
+		}(),
+	} {
+		_, err = aMockGen.Generate(iDesc)
+		if !errors.Is(err, amockgen.ErrNameCollision) {
+			t.Errorf("unexpected error '%v'", err)
+		}
+	}
+}
+
+func TestLoggerMock(t *testing.T) {
+	logger := testdata_amockgen.NewLoggerMockWithT(t)
+	logger.RegisterNLog(2, func(p0 string, p1 ...interface{}) (r0 int) {
+		return len(p1)
+	})
+	if n := logger.Log("%v %v", 1, 2); n != 2 {
+		t.Errorf("unexpected result, want '%v', actual '%v'", 2, n)
+	}
+	if n := logger.Log("no args"); n != 0 {
+		t.Errorf("unexpected result, want '%v', actual '%v'", 0, n)
+	}
+	var _ testdata_amockgen.Logger = logger
+}
+
+func TestLoggerTypedMock(t *testing.T) {
+	logger := testdata_amockgen.NewLoggerTypedMockWithT(t)
+	logger.RegisterLog(func(p0 string, p1 ...interface{}) (r0 int) {
+		return len(p1)
+	})
+	if n := logger.Log("%v", 1); n != 1 {
+		t.Errorf("unexpected result, want '%v', actual '%v'", 1, n)
+	}
+	var _ testdata_amockgen.Logger = logger
+}
+
+func TestStoreMock(t *testing.T) {
+	store := testdata_amockgen.NewStoreMockWithT[string, io.Reader](t)
+	store.RegisterGet(func(p0 string) (r0 io.Reader, r1 bool) {
+		return nil, false
+	}).RegisterPut(func(p0 string, p1 io.Reader) (r0 error) {
+		return nil
+	}).RegisterKeys(func() (r0 []string) {
+		return []string{"key"}
+	})
+	if r0, r1 := store.Get("key"); r0 != nil || r1 {
+		t.Errorf("unexpected Get() result, want '<nil> false', actual '%v %v'",
+			r0, r1)
+	}
+	if err := store.Put("key", nil); err != nil {
+		t.Errorf("unexpected error, want '%v', actual '%v'", nil, err)
+	}
+	if keys := store.Keys(); !reflect.DeepEqual(keys, []string{"key"}) {
+		t.Errorf("unexpected keys, want '%v', actual '%v'", []string{"key"},
+			keys)
+	}
+	var _ testdata_amockgen.Store[string, io.Reader] = store
+}
+
+func TestStoreItemMock(t *testing.T) {
+	store := testdata_amockgen.NewStoreStringItemMockWithT(t)
+	store.RegisterPut(func(p0 string, p1 v1_mock.Item) (r0 error) {
+		return nil
+	}).RegisterGet(func(p0 string) (r0 v1_mock.Item, r1 bool) {
+		return v1_mock.Item{}, p0 == "key"
+	})
+	if err := store.Put("key", v1_mock.Item{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.Get("key"); !ok {
+		t.Error("unexpected result")
+	}
+	var _ testdata_amockgen.Store[string, v1_mock.Item] = store
+}
+
+func TestStoreTypedMock(t *testing.T) {
+	store := testdata_amockgen.NewStoreTypedMockWithT[int, string](t)
+	store.RegisterGet(func(p0 int) (r0 string, r1 bool) {
+		return "value", true
+	})
+	if r0, r1 := store.Get(1); r0 != "value" || !r1 {
+		t.Errorf("unexpected Get() result, want 'value true', actual '%v %v'",
+			r0, r1)
+	}
+	var _ testdata_amockgen.Store[int, string] = store
+}
+
+func TestSourceMock(t *testing.T) {
+	source := testdata_amockgen.NewSourceMockWithT(t)
+	source.RegisterWrite(func(p []byte) (n int, err error) {
+		return len(p), nil
+	}).RegisterDo(func(v any, p1 string, p2 int, p3 interface{}) (r0 error) {
+		if v != nil || p3 != nil {
+			t.Errorf("unexpected params, want '<nil> <nil>', actual '%v %v'", v,
+				p3)
+		}
+		return nil
+	})
+	if n, err := source.Write([]byte("abc")); n != 3 || err != nil {
+		t.Errorf("unexpected Write() result, want '3 <nil>', actual '%v %v'", n,
+			err)
+	}
+	if err := source.Do(nil, "", 0, nil); err != nil {
+		t.Errorf("unexpected error, want '%v', actual '%v'", nil, err)
+	}
+	var _ testdata_amockgen.Source = source
+}
+
+func BenchmarkReaderMock(b *testing.B) {
+	reader := testdata_amockgen.NewReaderMock().RegisterTimesRead(
+		core.AnyTimes(), func(p0 []byte) (n int, err error) {
+			return len(p0), nil
+		})
+	p := make([]byte, 8)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		reader.Read(p)
+		// The history grows with each call, so it's reset outside of the timed
+		// section to measure the call itself.
+		if i%1024 == 1023 {
+			b.StopTimer()
+			reader.ResetHistory()
+			b.StartTimer()
+		}
+	}
+}
+
+func BenchmarkReaderTypedMock(b *testing.B) {
+	reader := testdata_amockgen.NewReaderTypedMock().RegisterTimesRead(
+		core.AnyTimes(), func(p0 []byte) (n int, err error) {
+			return len(p0), nil
+		})
+	p := make([]byte, 8)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		reader.Read(p)
+	}
+}
@@ -0,0 +1,248 @@
+package amock
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"sync"
+
+	"github.com/ymz-ncnk/amock/amockgen"
+	"github.com/ymz-ncnk/amock/parser"
+	"golang.org/x/tools/imports"
+)
+
+// Spec specifies an interface for GenerateAll.
+type Spec struct {
+	Type reflect.Type
+	Conf Conf
+	// File is the name of the generated file without the FilenameExtenstion.
+	// Mock implementations of all specs with the same File and Conf.Path are
+	// generated into one file, so they must have the same Conf.Package,
+	// Conf.BuildTags and Conf.Header, and the file is type-checked if at least
+	// one of them has Conf.TypeCheck. If File is empty, the mock
+	// implementation gets its own file, named by the Conf.File pattern.
+	File string
+}
+
+// Result is the result of the Spec generation.
+type Result struct {
+	Spec Spec
+	// Filename is the path of the generated file.
+	Filename string
+	Err      error
+}
+
+// batchFile is a file generated by GenerateAll.
+type batchFile struct {
+	filename string
+	specs    []int // Indices of the specs.
+	data     []byte
+}
+
+// GenerateAll generates mock implementations for all specs. Files are
+// generated concurrently, and then saved in the order of the specs. Unlike
+// GenerateAs, it doesn't stop at the first failure, results[i] reports the
+// generation of the specs[i], and err joins errors of all the specs.
+func (aMock AMock) GenerateAll(specs []Spec) (results []Result, err error) {
+	results = make([]Result, len(specs))
+	files := groupSpecs(specs, results)
+	wg := sync.WaitGroup{}
+	for _, file := range files {
+		wg.Add(1)
+		go func(file *batchFile) {
+			defer wg.Done()
+			aMock.generateFile(file, specs, results)
+		}(file)
+	}
+	wg.Wait()
+	for _, file := range files {
+		if file.data == nil {
+			continue
+		}
+		err := aMock.persistor.Persist(filepath.Base(file.filename), file.data,
+			filepath.Dir(file.filename))
+		if err != nil {
+			for _, i := range file.specs {
+				if results[i].Err == nil {
+					results[i].Err = err
+				}
+			}
+		}
+	}
+	errs := []error{}
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%v: %w", result.Spec.Type, result.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// groupSpecs groups the specs by the files, in the order of the specs.
+func groupSpecs(specs []Spec, results []Result) (files []*batchFile) {
+	byName := map[string]*batchFile{}
+	for i, spec := range specs {
+		results[i].Spec = spec
+		name := spec.File + FilenameExtenstion
+		if spec.File == "" {
+			data := FileData{}
+			if spec.Type != nil {
+				data.Interface = parser.TypeName(spec.Type)
+			}
+			data.Name = mockName(data.Interface, spec.Conf)
+			var err error
+			if name, err = makeFilename(spec.Conf.File, data); err != nil {
+				results[i].Err = err
+				continue
+			}
+		}
+		filename := filepath.Join(spec.Conf.Path, name)
+		results[i].Filename = filename
+		file, ok := byName[filename]
+		switch {
+		case !ok:
+			file = &batchFile{filename: filename}
+			byName[filename] = file
+			files = append(files, file)
+		case spec.File == "" || specs[file.specs[0]].File == "":
+			results[i].Err = fmt.Errorf("%v is generated by several specs",
+				filename)
+			continue
+		}
+		file.specs = append(file.specs, i)
+	}
+	return
+}
+
+// generateFile generates the file, setting errors of the specs, that have
+// failed, to the results. If all of them have failed, file.data stays nil.
+func (aMock AMock) generateFile(file *batchFile, specs []Spec,
+	results []Result) {
+	var (
+		first  = specs[file.specs[0]].Conf
+		valid  = []int{}
+		tps    = []reflect.Type{}
+		srcs   = [][]byte{}
+		descs  = []amockgen.MockImplDesc{}
+		check  = false
+		names  = map[string]bool{}
+		failed = func(i int, err error) { results[i].Err = err }
+	)
+	for _, i := range file.specs {
+		conf := specs[i].Conf
+		if conf.Package != first.Package || conf.BuildTags != first.BuildTags ||
+			conf.Header != first.Header {
+			failed(i, fmt.Errorf("package, build tags or header differ from the other mocks of %v",
+				file.filename))
+			continue
+		}
+		if specs[i].Type == nil {
+			failed(i, parser.ErrNotInterface)
+			continue
+		}
+		valid = append(valid, i)
+		tps = append(tps, specs[i].Type)
+	}
+	iDescs, errs := parser.ParseAll(tps...)
+	ok := []int{}
+	for j, i := range valid {
+		if errs[j] != nil {
+			failed(i, errs[j])
+			continue
+		}
+		iDesc, err := configure(iDescs[j], specs[i].Conf)
+		if err == nil && names[iDesc.Name] {
+			err = fmt.Errorf("%v mock implementation is already generated into %v",
+				iDesc.Name, file.filename)
+		}
+		var src []byte
+		if err == nil {
+			src, err = aMock.aMockGen.Generate(iDesc)
+		}
+		if err != nil {
+			failed(i, err)
+			continue
+		}
+		names[iDesc.Name] = true
+		srcs = append(srcs, src)
+		descs = append(descs, iDesc)
+		check = check || specs[i].Conf.TypeCheck
+		ok = append(ok, i)
+	}
+	if len(srcs) == 0 {
+		return
+	}
+	data, err := mergeSources(srcs)
+	if err == nil {
+		data, err = imports.Process("", data, nil)
+	}
+	if err == nil && check {
+		err = typeCheck(filepath.Dir(file.filename), filepath.Base(file.filename),
+			data, descs...)
+	}
+	if err != nil {
+		for _, i := range ok {
+			failed(i, err)
+		}
+		return
+	}
+	file.data = data
+}
+
+// mergeSources merges generated files of one package into one file. Header and
+// package clause are taken from the first file, imports are deduplicated.
+func mergeSources(srcs [][]byte) (data []byte, err error) {
+	if len(srcs) == 1 {
+		return srcs[0], nil
+	}
+	var (
+		header  []byte
+		specs   = []string{}
+		bodies  = [][]byte{}
+		visited = map[string]bool{}
+	)
+	for i, src := range srcs {
+		fset := token.NewFileSet()
+		f, err := goparser.ParseFile(fset, "", src,
+			goparser.ImportsOnly|goparser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			header = src[:fset.Position(f.Name.End()).Offset]
+		}
+		end := f.Name.End()
+		for _, decl := range f.Decls {
+			if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.IMPORT {
+				end = genDecl.End()
+			}
+		}
+		for _, imp := range f.Imports {
+			spec := imp.Path.Value
+			if imp.Name != nil {
+				spec = imp.Name.Name + " " + spec
+			}
+			if !visited[spec] {
+				visited[spec] = true
+				specs = append(specs, spec)
+			}
+		}
+		bodies = append(bodies, src[fset.Position(end).Offset:])
+	}
+	buf := bytes.NewBuffer(append([]byte{}, header...))
+	buf.WriteString("\n\nimport (\n")
+	for _, spec := range specs {
+		buf.WriteString("\t" + spec + "\n")
+	}
+	buf.WriteString(")\n")
+	for _, body := range bodies {
+		buf.Write(body)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,117 @@
+package amock
+
+import (
+	"bytes"
+	"errors"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	parser_mod "github.com/ymz-ncnk/amock/parser"
+	v1_mock "github.com/ymz-ncnk/amock/testdata/amockgen/v1/mock"
+	v2_mock "github.com/ymz-ncnk/amock/testdata/amockgen/v2/mock"
+)
+
+type V1Getter interface {
+	Get() v1_mock.Item
+}
+
+type V2Getter interface {
+	Get() *v2_mock.Item
+}
+
+func TestGenerateAll(t *testing.T) {
+	var (
+		dir    = t.TempDir()
+		conf   = Conf{Package: "mock", Path: dir}
+		reader = reflect.TypeOf((*io.Reader)(nil)).Elem()
+		writer = reflect.TypeOf((*io.Writer)(nil)).Elem()
+		v1     = reflect.TypeOf((*V1Getter)(nil)).Elem()
+		v2     = reflect.TypeOf((*V2Getter)(nil)).Elem()
+		specs  = []Spec{
+			{Type: reader, Conf: conf, File: "io"},
+			{Type: writer, Conf: conf, File: "io"},
+			{Type: v1, Conf: conf, File: "getters"},
+			{Type: v2, Conf: conf, File: "getters"},
+			{Type: reader, Conf: Conf{Package: "mock", Path: dir, Typed: true,
+				Name: "ReaderTypedMock"}},
+			{Type: reflect.TypeOf(V1Getter(nil)), Conf: conf, File: "io"},
+			{Type: writer, Conf: conf, File: "io"},
+			{Type: writer, Conf: Conf{Package: "other", Path: dir}, File: "io"},
+			{Type: reader, Conf: conf, File: "ReaderTypedMock"},
+		}
+	)
+	aMock, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := aMock.GenerateAll(specs)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if len(results) != len(specs) {
+		t.Fatalf("unexpected results count %v", len(results))
+	}
+	for i, want := range []string{"io", "io", "getters", "getters",
+		"ReaderTypedMock", "io", "io", "io", "ReaderTypedMock"} {
+		filename := filepath.Join(dir, want+FilenameExtenstion)
+		if results[i].Filename != filename {
+			t.Errorf("unexpected results[%v].Filename '%v'", i, results[i].Filename)
+		}
+		if results[i].Spec.Type != specs[i].Type {
+			t.Errorf("unexpected results[%v].Spec", i)
+		}
+		if (i < 5) != (results[i].Err == nil) {
+			t.Errorf("unexpected results[%v].Err '%v'", i, results[i].Err)
+		}
+	}
+	if !errors.Is(results[5].Err, parser_mod.ErrNotInterface) {
+		t.Errorf("unexpected results[5].Err '%v'", results[5].Err)
+	}
+
+	data := readGenerated(t, results[0].Filename)
+	for _, str := range []string{"type Reader struct", "type Writer struct"} {
+		if bytes.Count(data, []byte(str)) != 1 {
+			t.Errorf("no '%v' in '%s'", str, data)
+		}
+	}
+	data = readGenerated(t, results[2].Filename)
+	for _, str := range []string{
+		`"github.com/ymz-ncnk/amock/testdata/amockgen/v1/mock"`,
+		`mock2 "github.com/ymz-ncnk/amock/testdata/amockgen/v2/mock"`,
+		"mock.Item", "*mock2.Item",
+	} {
+		if !bytes.Contains(data, []byte(str)) {
+			t.Errorf("no '%v' in '%s'", str, data)
+		}
+	}
+	data = readGenerated(t, results[4].Filename)
+	if !bytes.Contains(data, []byte("type ReaderTypedMock struct")) {
+		t.Errorf("unexpected content '%s'", data)
+	}
+	if msg := err.Error(); !strings.Contains(msg,
+		"io.Writer: Writer mock implementation is already generated") ||
+		!strings.Contains(msg, "is generated by several specs") {
+		t.Errorf("unexpected error '%v'", err)
+	}
+}
+
+func readGenerated(t *testing.T, filename string) []byte {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Count(data, []byte(GeneratedHeader)) != 1 {
+		t.Errorf("unexpected header of %v", filename)
+	}
+	if _, err = parser.ParseFile(token.NewFileSet(), filename, data,
+		parser.AllErrors); err != nil {
+		t.Error(err)
+	}
+	return data
+}
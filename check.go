@@ -0,0 +1,37 @@
+package amock
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// CheckPersistor compares the data with the file on the disk instead of
+// saving it. If they differ returns OutdatedError and writes the diff to the
+// Out, if it is not nil.
+type CheckPersistor struct {
+	Out io.Writer
+}
+
+// Persist checks that the "path/name" file contains the data.
+func (persistor CheckPersistor) Persist(name string, data []byte,
+	path string) (err error) {
+	filename := filepath.Join(path, name)
+	actual, err := os.ReadFile(filename)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	diff := unifiedDiff(filename, filename+" (generated)", string(actual),
+		string(data))
+	if diff == "" {
+		return nil
+	}
+	if persistor.Out != nil {
+		if _, err = io.WriteString(persistor.Out, diff); err != nil {
+			return
+		}
+	}
+	return NewOutdatedError(filename, diff)
+}
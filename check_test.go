@@ -0,0 +1,58 @@
+package amock
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	var (
+		tp   = reflect.TypeOf((*io.Reader)(nil)).Elem()
+		conf = Conf{Package: "mock", Name: "ReaderMock", Path: t.TempDir()}
+		file = filepath.Join(conf.Path, conf.Name+FilenameExtenstion)
+	)
+	aMock, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var outdatedErr *OutdatedError
+	if err = aMock.Check(tp, conf); !errors.As(err, &outdatedErr) {
+		t.Fatalf("unexpected error '%v'", err)
+	}
+	if _, err = os.Stat(file); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("check created the file '%v'", err)
+	}
+	if err = aMock.GenerateAs(tp, conf); err != nil {
+		t.Fatal(err)
+	}
+	if err = aMock.Check(tp, conf); err != nil {
+		t.Errorf("unexpected error '%v'", err)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = bytes.Replace(data, []byte("package mock"), []byte("package old"), 1)
+	if err = os.WriteFile(file, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	out := &bytes.Buffer{}
+	err = NewWith(aMock.aMockGen, CheckPersistor{Out: out}).GenerateAs(tp, conf)
+	if !errors.As(err, &outdatedErr) {
+		t.Fatalf("unexpected error '%v'", err)
+	}
+	if outdatedErr.Filename() != file {
+		t.Errorf("unexpected filename '%v'", outdatedErr.Filename())
+	}
+	if diff := outdatedErr.Diff(); !strings.Contains(diff,
+		"-package old\n+package mock\n") || diff != out.String() {
+		t.Errorf("unexpected diff '%v'", diff)
+	}
+}
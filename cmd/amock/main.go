@@ -0,0 +1,384 @@
+// Command amock generates mock implementations of interfaces. Interfaces are
+// loaded from the source code, so generic ones are supported too.
+//
+// Usage:
+//
+//	amock [flags] -type io.Reader [-type pkg.Interface ...]
+//	amock [flags] ./pkg Interface1 Interface2
+//	amock ./...
+//	amock [-config amock.yaml]
+//
+// The third form generates mocks of the interfaces annotated with the
+// amock:generate directive. It's used only if all arguments look like package
+// patterns, i.e. start with "." or "/", or contain "/" or "...", like "./pkg"
+// or "example.com/pkg/...". Such annotations look like:
+//
+//	//amock:generate name=StoreMock path=testdata/mock
+//	type Store interface { ... }
+//
+// The last one generates mocks listed in the config file, see amock.Config. If
+// no arguments are specified, the config file is searched in the current
+// directory, see amock.ConfigFilenames.
+//
+// With the -check flag, mocks are not saved, but compared with the files on
+// the disk. Diffs of the outdated ones are printed to the stdout.
+//
+// With the -typecheck flag, the generated files are type-checked, and the
+// broken ones are not saved, see amock.Conf.TypeCheck.
+//
+// With the -stdout flag, mocks are not saved, but printed to the stdout, each
+// one preceded by the comment with the file name.
+//
+// The generated files are recorded in the .amock.json manifests of the output
+// directories, see amock.ManifestPersistor. With the -prune flag, after the
+// successful generation, the generated files, that are listed in the manifests
+// of the output directories, or of any directory under the package patterns or
+// the config file directory, but were not generated this time, are removed,
+// see amock.AMock.Prune.
+//
+// It can be used from the go:generate directive:
+//
+//	//go:generate go run github.com/ymz-ncnk/amock/cmd/amock -type io.Reader
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ymz-ncnk/amock"
+	"github.com/ymz-ncnk/amock/amockgen/text_template"
+	"github.com/ymz-ncnk/amock/parser"
+)
+
+// Exit codes.
+const (
+	exitOk = iota
+	exitFailure
+	exitUsage
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// spec specifies an interface to generate mock implementation for.
+type spec struct {
+	pattern string // Package pattern.
+	name    string // Interface name.
+}
+
+func (s spec) String() string {
+	return s.pattern + "." + s.name
+}
+
+// typesFlag is a repeatable -type flag.
+type typesFlag []spec
+
+func (f *typesFlag) String() string {
+	strs := make([]string, len(*f))
+	for i, s := range *f {
+		strs[i] = s.String()
+	}
+	return strings.Join(strs, ",")
+}
+
+func (f *typesFlag) Set(value string) error {
+	i := strings.LastIndex(value, ".")
+	if i <= 0 || i == len(value)-1 {
+		return fmt.Errorf("%q is not a package qualified interface name, like io.Reader",
+			value)
+	}
+	*f = append(*f, spec{pattern: value[:i], name: value[i+1:]})
+	return nil
+}
+
+// options are the flags, that are not a part of amock.Conf.
+type options struct {
+	check  bool
+	prune  bool
+	print  bool
+	stdout io.Writer
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	var (
+		flags      = flag.NewFlagSet("amock", flag.ContinueOnError)
+		types      typesFlag
+		conf       amock.Conf
+		configFile string
+		opts       = options{stdout: stdout}
+	)
+	flags.SetOutput(stderr)
+	flags.Var(&types, "type",
+		"package qualified interface `name`, like io.Reader, may be repeated")
+	flags.StringVar(&conf.Package, "pkg", amock.DefConf.Package,
+		"package of the generated mocks")
+	flags.StringVar(&conf.Path, "out", amock.DefConf.Path,
+		"output directory")
+	flags.StringVar(&conf.Name, "name", "",
+		"name of the generated mock, only for a single interface")
+	flags.StringVar(&conf.File, "file", "",
+		"`pattern` of the generated file names, like {{.Interface | snake}}_mock.go")
+	flags.StringVar(&conf.Constructor, "constructor", "",
+		"name of the generated mock constructor, only for a single interface")
+	flags.BoolVar(&conf.Typed, "typed", false,
+		"generate reflection-free mocks")
+	flags.BoolVar(&conf.TypeCheck, "typecheck", false,
+		"type-check the generated files, and don't save the broken ones")
+	flags.StringVar(&conf.BuildTags, "tags", "",
+		"build constraint `expression` of the generated files")
+	flags.StringVar(&conf.Header, "header", "",
+		"comment at the top of the generated files, like a license")
+	flags.StringVar(&configFile, "config", "",
+		"config `file`, like amock.yaml")
+	flags.BoolVar(&opts.check, "check", false,
+		"check that the generated files are up to date, without changing them")
+	flags.BoolVar(&opts.prune, "prune", false,
+		"remove the stale generated files of the output directories")
+	flags.BoolVar(&opts.print, "stdout", false,
+		"print the generated files to the stdout instead of saving them")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: amock [flags] -type pkg.Interface ...")
+		fmt.Fprintln(stderr, "       amock [flags] package Interface ...")
+		fmt.Fprintln(stderr, "       amock package ...")
+		fmt.Fprintln(stderr, "       amock [-config file]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if err := exclusive(opts); err != nil {
+		fmt.Fprintf(stderr, "amock: %v\n", err)
+		flags.Usage()
+		return exitUsage
+	}
+	if configFile == "" && len(types) == 0 && flags.NArg() == 0 {
+		configFile = amock.FindConfig(".")
+	}
+	if configFile != "" {
+		return runConfig(flags, configFile, opts, stderr)
+	}
+	if len(types) == 0 && annotated(flags.Args()) {
+		return runAnnotated(flags, opts, stderr)
+	}
+	specs, err := makeSpecs(types, flags.Args())
+	switch {
+	case err != nil || len(specs) == 1:
+	case conf.Name != "":
+		err = errors.New("-name is set for several interfaces")
+	case conf.Constructor != "":
+		err = errors.New("-constructor is set for several interfaces")
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "amock: %v\n", err)
+		flags.Usage()
+		return exitUsage
+	}
+	aMock, err := newAMock(opts)
+	if err == nil && !opts.check && !opts.print {
+		err = os.MkdirAll(conf.Path, 0755)
+	}
+	if err != nil {
+		printError(stderr, err)
+		return exitFailure
+	}
+	status := exitOk
+	for _, s := range specs {
+		if err = aMock.GenerateSource(s.pattern, s.name, conf); err != nil {
+			fmt.Fprintf(stderr, "amock: %v: %v\n", s, err)
+			status = exitFailure
+		}
+	}
+	if status == exitOk {
+		status = prune(aMock, opts, stderr)
+	}
+	return status
+}
+
+// exclusive returns an error if the mutually exclusive flags are set.
+func exclusive(opts options) error {
+	switch {
+	case opts.check && opts.prune:
+		return errors.New("-prune can't be used with -check")
+	case opts.print && opts.check:
+		return errors.New("-stdout can't be used with -check")
+	case opts.print && opts.prune:
+		return errors.New("-stdout can't be used with -prune")
+	}
+	return nil
+}
+
+// annotated returns true if all args are package patterns, see isPattern.
+func annotated(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	for _, arg := range args {
+		if !isPattern(arg) {
+			return false
+		}
+	}
+	return true
+}
+
+// isPattern returns true if the arg looks like a package pattern, like "./pkg",
+// "example.com/pkg" or "std/...", rather than an interface name.
+func isPattern(arg string) bool {
+	return strings.HasPrefix(arg, ".") || strings.HasPrefix(arg, "/") ||
+		strings.Contains(arg, "/") || strings.Contains(arg, "...")
+}
+
+// patternRoots returns directories of the local package patterns, like "." for
+// "./...".
+func patternRoots(patterns []string) (roots []string) {
+	for _, pattern := range patterns {
+		if !strings.HasPrefix(pattern, ".") && !filepath.IsAbs(pattern) {
+			continue
+		}
+		dir := strings.TrimSuffix(strings.TrimSuffix(pattern, "..."), "/")
+		if dir == "" {
+			dir = "."
+		}
+		roots = append(roots, dir)
+	}
+	return
+}
+
+// runAnnotated generates mocks of the annotated interfaces, the flags are
+// replaced by the directive arguments.
+func runAnnotated(flags *flag.FlagSet, opts options, stderr io.Writer) int {
+	if set := setFlags(flags, "check", "prune", "stdout"); len(set) > 0 {
+		fmt.Fprintf(stderr, "amock: %v can't be used with the %v directive\n",
+			strings.Join(set, ", "), parser.Directive)
+		flags.Usage()
+		return exitUsage
+	}
+	aMock, err := newAMock(opts)
+	if err == nil {
+		err = aMock.GenerateAnnotated(flags.Args()...)
+	}
+	if err != nil {
+		printError(stderr, err)
+		return exitFailure
+	}
+	return prune(aMock, opts, stderr, patternRoots(flags.Args())...)
+}
+
+// runConfig generates mocks listed in the config file, the flags are replaced
+// by the config.
+func runConfig(flags *flag.FlagSet, configFile string, opts options,
+	stderr io.Writer) int {
+	set := setFlags(flags, "config", "check", "prune", "stdout")
+	if len(set) > 0 || flags.NArg() > 0 {
+		fmt.Fprintf(stderr, "amock: %v can't be used with the config file\n",
+			strings.Join(append(set, flags.Args()...), ", "))
+		flags.Usage()
+		return exitUsage
+	}
+	config, err := amock.LoadConfig(configFile)
+	if err != nil {
+		printError(stderr, err)
+		return exitFailure
+	}
+	for _, entry := range config.Mocks {
+		if opts.check || opts.print {
+			break
+		}
+		if err = os.MkdirAll(entry.Path, 0755); err != nil {
+			printError(stderr, err)
+			return exitFailure
+		}
+	}
+	aMock, err := newAMock(opts)
+	if err == nil {
+		err = aMock.GenerateConfig(config)
+	}
+	if err != nil {
+		printError(stderr, err)
+		return exitFailure
+	}
+	return prune(aMock, opts, stderr, filepath.Dir(configFile))
+}
+
+// prune removes the stale generated files with the -prune flag, see
+// amock.AMock.Prune, and prints their names to the stdout.
+func prune(aMock amock.AMock, opts options, stderr io.Writer,
+	roots ...string) int {
+	if !opts.prune {
+		return exitOk
+	}
+	removed, err := aMock.Prune(roots...)
+	for _, filename := range removed {
+		fmt.Fprintf(opts.stdout, "removed %v\n", filename)
+	}
+	if err != nil {
+		printError(stderr, err)
+		return exitFailure
+	}
+	return exitOk
+}
+
+// newAMock creates AMock, that checks the generated files with the -check
+// flag, prints them to the stdout with the -stdout flag, or saves them to the
+// disk.
+func newAMock(opts options) (aMock amock.AMock, err error) {
+	if !opts.check && !opts.print {
+		return amock.NewWithManifest()
+	}
+	aMockGen, err := text_template.New()
+	if err != nil {
+		return
+	}
+	if opts.print {
+		return amock.NewWith(aMockGen, amock.NewWriterPersistor(opts.stdout)), nil
+	}
+	return amock.NewWith(aMockGen, amock.CheckPersistor{Out: opts.stdout}), nil
+}
+
+// setFlags returns names of the set flags, except the specified ones.
+func setFlags(flags *flag.FlagSet, except ...string) (names []string) {
+	flags.Visit(func(f *flag.Flag) {
+		for _, name := range except {
+			if f.Name == name {
+				return
+			}
+		}
+		names = append(names, "-"+f.Name)
+	})
+	return
+}
+
+// printError prints each line of the error, which may be joined from several
+// ones, with the "amock: " prefix.
+func printError(stderr io.Writer, err error) {
+	for _, line := range strings.Split(err.Error(), "\n") {
+		fmt.Fprintf(stderr, "amock: %v\n", line)
+	}
+}
+
+// makeSpecs makes specs either from the -type flags, or from the package
+// pattern followed by the interface names.
+func makeSpecs(types typesFlag, args []string) (specs []spec, err error) {
+	switch {
+	case len(types) > 0 && len(args) > 0:
+		err = errors.New("-type flag can't be used with the positional arguments")
+	case len(types) > 0:
+		specs = types
+	case len(args) > 1:
+		for _, name := range args[1:] {
+			if !token.IsIdentifier(name) {
+				return nil, fmt.Errorf("%q is not an interface name", name)
+			}
+			specs = append(specs, spec{pattern: args[0], name: name})
+		}
+	default:
+		err = errors.New("no interfaces are specified")
+	}
+	return
+}
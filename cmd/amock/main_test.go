@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+
+	t.Run("Usage errors", func(t *testing.T) {
+		for _, args := range [][]string{
+			{},
+			{"-pkg", "mock", "./..."},
+			{"-config", "amock.yaml", "-typed"},
+			{"-config", "amock.yaml", "./..."},
+			{"-type", "Reader"},
+			{"-type", "io.Reader", "io", "Writer"},
+			{"-name", "Mock", "io", "Reader", "Writer"},
+			{"-check", "-prune", "io", "Reader"},
+			{"-stdout", "-check", "io", "Reader"},
+			{"-stdout", "-prune", "io", "Reader"},
+			{"-constructor", "NewMock", "io", "Reader", "Writer"},
+			{"io"},
+			{"./pkg", "Reader", "./other"},
+			{"./pkg", "io.Reader"},
+			{"-unknown"},
+		} {
+			stderr := &bytes.Buffer{}
+			if status := run(args, io.Discard, stderr); status != exitUsage {
+				t.Errorf("unexpected status for %v, want '%v', actual '%v'", args,
+					exitUsage, status)
+			}
+			if !strings.Contains(stderr.String(), "usage: amock") {
+				t.Errorf("no usage for %v in '%v'", args, stderr)
+			}
+		}
+	})
+
+	t.Run("Generate", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "mock")
+		stderr := &bytes.Buffer{}
+		status := run([]string{"-out", out, "-typecheck", "io", "Reader",
+			"Writer"}, io.Discard, stderr)
+		if status != exitOk {
+			t.Fatalf("unexpected status '%v', stderr '%v'", status, stderr)
+		}
+		for _, name := range []string{"Reader.gen.go", "Writer.gen.go"} {
+			data, err := os.ReadFile(filepath.Join(out, name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Contains(data, []byte("package mock")) {
+				t.Errorf("unexpected %v content '%s'", name, data)
+			}
+		}
+	})
+
+	t.Run("Check", func(t *testing.T) {
+		out := t.TempDir()
+		args := []string{"-out", out, "-type", "io.Reader"}
+		if status := run(args, io.Discard, io.Discard); status != exitOk {
+			t.Fatalf("unexpected status '%v'", status)
+		}
+		stdout := &bytes.Buffer{}
+		args = append([]string{"-check"}, args...)
+		if status := run(args, stdout, io.Discard); status != exitOk {
+			t.Errorf("unexpected status '%v'", status)
+		}
+		args[2] = filepath.Join(out, "new")
+		stderr := &bytes.Buffer{}
+		if status := run(args, stdout, stderr); status != exitFailure {
+			t.Errorf("unexpected status '%v'", status)
+		}
+		if _, err := os.Stat(args[2]); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("check created the directory '%v'", err)
+		}
+		if !strings.Contains(stderr.String(), "Reader.gen.go is out of date") ||
+			!strings.HasPrefix(stdout.String(), "--- "+args[2]) {
+			t.Errorf("unexpected stderr '%v' or stdout '%v'", stderr, stdout)
+		}
+	})
+
+	t.Run("Prune", func(t *testing.T) {
+		out := t.TempDir()
+		args := []string{"-out", out, "io", "Reader", "Writer"}
+		if status := run(args, io.Discard, io.Discard); status != exitOk {
+			t.Fatalf("unexpected status '%v'", status)
+		}
+		stdout := &bytes.Buffer{}
+		args = []string{"-prune", "-out", out, "io", "Reader"}
+		if status := run(args, stdout, io.Discard); status != exitOk {
+			t.Fatalf("unexpected status '%v'", status)
+		}
+		removed := filepath.Join(out, "Writer.gen.go")
+		if want := "removed " + removed + "\n"; stdout.String() != want {
+			t.Errorf("unexpected stdout, want '%v', actual '%v'", want, stdout)
+		}
+		if _, err := os.Stat(removed); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("stale file was not removed '%v'", err)
+		}
+	})
+
+	t.Run("Stdout", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "mock")
+		stdout := &bytes.Buffer{}
+		args := []string{"-stdout", "-out", out, "io", "Reader", "Writer"}
+		if status := run(args, stdout, io.Discard); status != exitOk {
+			t.Fatalf("unexpected status '%v'", status)
+		}
+		for _, name := range []string{"Reader.gen.go", "Writer.gen.go"} {
+			if !strings.Contains(stdout.String(),
+				"// "+filepath.Join(out, name)+"\n// Code generated") {
+				t.Errorf("no %v in '%v'", name, stdout)
+			}
+		}
+		if _, err := os.Stat(out); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("stdout created the directory '%v'", err)
+		}
+	})
+
+	t.Run("Annotated", func(t *testing.T) {
+		stderr := &bytes.Buffer{}
+		status := run([]string{"../../testdata/annotated/invalid"}, io.Discard,
+			stderr)
+		if status != exitFailure {
+			t.Errorf("unexpected status '%v'", status)
+		}
+		if !strings.Contains(stderr.String(), "not interface") {
+			t.Errorf("unexpected stderr '%v'", stderr)
+		}
+	})
+
+	t.Run("Config", func(t *testing.T) {
+		dir := t.TempDir()
+		configFile := filepath.Join(dir, "amock.json")
+		err := os.WriteFile(configFile, []byte(`{"mocks": [
+			{"type": "io.Reader", "path": "mock", "tags": "!race"},
+			{"type": "io.Raeder"}
+		]}`), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stderr := &bytes.Buffer{}
+		status := run([]string{"-config", configFile}, io.Discard, stderr)
+		if status != exitFailure {
+			t.Errorf("unexpected status '%v'", status)
+		}
+		if !strings.Contains(stderr.String(), "unknown field") {
+			t.Errorf("unexpected stderr '%v'", stderr)
+		}
+		err = os.WriteFile(configFile, []byte(`{"mocks": [
+			{"type": "io.Reader", "path": "mock", "build_tags": "!race"},
+			{"type": "io.Raeder"}
+		]}`), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stderr.Reset()
+		status = run([]string{"-config", configFile}, io.Discard, stderr)
+		if status != exitFailure {
+			t.Errorf("unexpected status '%v'", status)
+		}
+		if want := "amock: io.Raeder: type not found\n"; stderr.String() != want {
+			t.Errorf("unexpected stderr, want '%v', actual '%v'", want, stderr)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "mock", "Reader.gen.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Contains(data, []byte("//go:build !race")) {
+			t.Errorf("unexpected content '%s'", data)
+		}
+	})
+
+	t.Run("Generation error", func(t *testing.T) {
+		stderr := &bytes.Buffer{}
+		status := run([]string{"-out", t.TempDir(), "-type", "io.Raeder",
+			"-type", "io.Writer"}, io.Discard, stderr)
+		if status != exitFailure {
+			t.Errorf("unexpected status '%v'", status)
+		}
+		if want := "amock: io.Raeder: type not found\n"; stderr.String() != want {
+			t.Errorf("unexpected stderr, want '%v', actual '%v'", want, stderr)
+		}
+	})
+}
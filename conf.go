@@ -2,7 +2,28 @@ package amock
 
 // Conf configures the generation process.
 type Conf struct {
-	Package string // Package of the generated mock implementation.
-	Name    string // Name of the generated file and mock implementation type.
-	Path    string // Path of the generated file.
+	// Package of the generated mock implementation.
+	Package string `json:"package,omitempty" yaml:"package,omitempty"`
+	// Name of the mock implementation type, the interface name by default.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// File is a text/template pattern of the generated file name, see
+	// DefFilePattern and FileData.
+	File string `json:"file,omitempty" yaml:"file,omitempty"`
+	// Constructor is the name of the mock implementation constructor, "New" +
+	// Name by default. The other constructors get the same prefix, like
+	// ConstructorWithT.
+	Constructor string `json:"constructor,omitempty" yaml:"constructor,omitempty"`
+	// Path of the generated file.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// If true, generates reflection-free core.TypedMock.
+	Typed bool `json:"typed,omitempty" yaml:"typed,omitempty"`
+	// Build constraint expression of the generated file, like "!race".
+	BuildTags string `json:"build_tags,omitempty" yaml:"build_tags,omitempty"`
+	// If true, the generated file is type-checked together with the other
+	// files of the target package, and must declare a mock implementation, that
+	// implements the interface. Otherwise it's not saved, see TypeCheckError.
+	TypeCheck bool `json:"type_check,omitempty" yaml:"type_check,omitempty"`
+	// Header is placed as a comment at the top of the generated file, like a
+	// license.
+	Header string `json:"header,omitempty" yaml:"header,omitempty"`
 }
@@ -0,0 +1,134 @@
+package amock
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFilenames are the default names of the config file, see LoadConfig.
+var ConfigFilenames = []string{"amock.yaml", "amock.yml", "amock.json"}
+
+// Config describes mock implementations to generate, like:
+//
+//	defaults:
+//	  path: testdata/mock
+//	mocks:
+//	  - type: io.Reader
+//	    name: ReaderMock
+//	  - type: ./store.Store
+//	    typed: true
+type Config struct {
+	// Defaults are used for the omitted fields of the Mocks entries, which in
+	// turn default to DefConf. Defaults.Name and Defaults.Constructor are
+	// ignored.
+	Defaults Conf `json:"defaults" yaml:"defaults"`
+	// Mocks lists interfaces to generate mock implementations for.
+	Mocks []ConfigEntry `json:"mocks" yaml:"mocks"`
+}
+
+// ConfigEntry configures generation of the mock implementation of the Type
+// interface.
+type ConfigEntry struct {
+	// Type is a package qualified interface name, like io.Reader or
+	// ./store.Store.
+	Type string `json:"type" yaml:"type"`
+	Conf `yaml:",inline"`
+}
+
+// Pattern returns the package pattern of the Type.
+func (entry ConfigEntry) Pattern() string {
+	return entry.Type[:strings.LastIndex(entry.Type, ".")]
+}
+
+// Interface returns the interface name of the Type.
+func (entry ConfigEntry) Interface() string {
+	return entry.Type[strings.LastIndex(entry.Type, ".")+1:]
+}
+
+// LoadConfig loads Config from the YAML (.yaml, .yml) or JSON (.json) file.
+// Unknown fields are not allowed. Relative paths of the generated files and
+// package patterns, like ./store, are resolved against the directory of the
+// file. Entries get their omitted fields from the Defaults (Typed and
+// TypeCheck are true if they are true in either of them).
+func LoadConfig(filename string) (config Config, err error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return
+	}
+	switch filepath.Ext(filename) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&config)
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&config)
+	default:
+		err = errors.New("unsupported config file extension")
+	}
+	if err != nil {
+		return config, fmt.Errorf("%v: %w", filename, err)
+	}
+	dir, err := filepath.Abs(filepath.Dir(filename))
+	if err != nil {
+		return
+	}
+	for i := range config.Mocks {
+		if config.Mocks[i], err = config.resolve(config.Mocks[i], dir); err != nil {
+			return config, fmt.Errorf("%v: mocks[%v]: %w", filename, i, err)
+		}
+	}
+	return
+}
+
+// FindConfig returns the name of the first ConfigFilenames file, that exists in
+// the dir, or an empty string.
+func FindConfig(dir string) string {
+	for _, name := range ConfigFilenames {
+		filename := filepath.Join(dir, name)
+		if _, err := os.Stat(filename); err == nil {
+			return filename
+		}
+	}
+	return ""
+}
+
+func (config Config) resolve(entry ConfigEntry, dir string) (ConfigEntry,
+	error) {
+	if i := strings.LastIndex(entry.Type, "."); i <= 0 ||
+		i == len(entry.Type)-1 {
+		return entry, fmt.Errorf("%q type is not a package qualified interface name",
+			entry.Type)
+	}
+	pattern := entry.Pattern()
+	if strings.HasPrefix(pattern, "./") || strings.HasPrefix(pattern, "../") ||
+		pattern == "." {
+		entry.Type = filepath.Join(dir, pattern) + "." + entry.Interface()
+	}
+	def := func(value *string, values ...string) {
+		for _, v := range values {
+			if *value == "" {
+				*value = v
+			}
+		}
+	}
+	def(&entry.Package, config.Defaults.Package, DefConf.Package)
+	def(&entry.Path, config.Defaults.Path, DefConf.Path)
+	def(&entry.File, config.Defaults.File)
+	def(&entry.BuildTags, config.Defaults.BuildTags)
+	def(&entry.Header, config.Defaults.Header)
+	entry.Typed = entry.Typed || config.Defaults.Typed
+	entry.TypeCheck = entry.TypeCheck || config.Defaults.TypeCheck
+	if !filepath.IsAbs(entry.Path) {
+		entry.Path = filepath.Join(dir, entry.Path)
+	}
+	return entry, nil
+}
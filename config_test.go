@@ -0,0 +1,113 @@
+package amock
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ymz-ncnk/amock/amockgen/text_template"
+	"github.com/ymz-ncnk/amock/core"
+	"github.com/ymz-ncnk/amock/testdata/mock"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := filepath.Abs("testdata/config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		header = "Copyright 2026 The AMock Authors.\nLicensed under the MIT License."
+		file   = "{{.Interface | snake}}_mock.go"
+	)
+	want := Config{
+		Defaults: Conf{Path: "testdata/mock", File: file, Header: header},
+		Mocks: []ConfigEntry{
+			{
+				Type: "io.Reader",
+				Conf: Conf{Package: "mock", Name: "ReaderMock",
+					File: file, Path: filepath.Join(dir, "testdata/mock"),
+					BuildTags: "!race", Header: header},
+			},
+			{
+				Type: filepath.Join(dir, "../annotated") + ".Storage",
+				Conf: Conf{Package: "storage_mock", File: file,
+					Path: "/tmp/storage_mock", Typed: true, Header: header,
+					Constructor: "NewStorage"},
+			},
+		},
+	}
+	for _, name := range []string{"amock.yaml", "amock.json"} {
+		config, err := LoadConfig(filepath.Join("testdata/config", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(config, want) {
+			t.Errorf("unexpected %v config, want '%v', actual '%v'", name, want,
+				config)
+		}
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, name := range []string{"unknown.yaml", "invalid.yaml",
+			"missing.yaml"} {
+			_, err := LoadConfig(filepath.Join("testdata/config", name))
+			if err == nil {
+				t.Errorf("%v: unexpected nil error", name)
+			}
+		}
+	})
+
+	t.Run("FindConfig", func(t *testing.T) {
+		if filename := FindConfig("testdata/config"); filename !=
+			filepath.Join("testdata/config", "amock.yaml") {
+			t.Errorf("unexpected filename '%v'", filename)
+		}
+		if filename := FindConfig("testdata"); filename != "" {
+			t.Errorf("unexpected filename '%v'", filename)
+		}
+	})
+}
+
+func TestGenerateConfig(t *testing.T) {
+	aMockGen, err := text_template.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		persistor = mock.NewPersistor()
+		wantErr   = errors.New("fail")
+		config    = Config{Mocks: []ConfigEntry{
+			{
+				Type: "io.Reader",
+				Conf: Conf{Package: "mock", Name: "ReaderMock", Path: "mock",
+					BuildTags: "!race", Header: "License."},
+			},
+			{Type: "io.Raeder", Conf: Conf{Package: "mock", Path: "mock"}},
+			{Type: "io.Writer", Conf: Conf{Package: "mock", Path: "mock"}},
+		}}
+	)
+	persistor.RegisterPersist(func(name string, data []byte,
+		path string) error {
+		if name != "ReaderMock.gen.go" || path != "mock" {
+			t.Errorf("unexpected name '%v' or path '%v'", name, path)
+		}
+		want := "// License.\n\n// Code generated by amockgen. DO NOT EDIT.\n\n" +
+			"//go:build !race\n\npackage mock\n"
+		if !bytes.HasPrefix(data, []byte(want)) {
+			t.Errorf("unexpected data, want prefix '%v', actual '%s'", want, data)
+		}
+		return nil
+	}).RegisterPersist(func(name string, data []byte, path string) error {
+		return wantErr
+	})
+	err = NewWith(aMockGen, persistor).GenerateConfig(config)
+	if !errors.Is(err, wantErr) || !strings.Contains(err.Error(), "io.Raeder") {
+		t.Errorf("unexpected error '%v'", err)
+	}
+	if result := CheckCalls([]*core.Mock{persistor.Mock}); len(result) > 0 {
+		t.Error(result)
+	}
+}
@@ -3,12 +3,22 @@ package core
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 )
 
 // ErrNotFunction happens during the registration of an object that is not a
 // function.
 var ErrNotFunction = errors.New("not a function")
 
+// ErrNotInterface happens when the interface type of a mock is not an
+// interface.
+var ErrNotInterface = errors.New("not an interface")
+
+// ErrInvalidTimes happens during the registration of a function with Times,
+// that can't be satisfied, see Times.Validate.
+var ErrInvalidTimes = errors.New("invalid number of calls")
+
 // ErrUnexpectedCall happens during an unexpected method call.
 var ErrUnexpectedCall = errors.New("unexpected call")
 
@@ -16,13 +26,15 @@ var ErrUnexpectedCall = errors.New("unexpected call")
 // NewUnexpectedMethodCallError creates new UnexpectedMethodCallError.
 func NewUnexpectedMethodCallError(mockName MockName,
 	methodName MethodName) *UnexpectedMethodCallError {
-	return &UnexpectedMethodCallError{mockName, methodName}
+	return &UnexpectedMethodCallError{mockName, methodName, nil}
 }
 
-// UnexpectedMethodCallError happens during an unexpected method call.
+// UnexpectedMethodCallError happens during an unexpected method call. It lists
+// registrations of the method.
 type UnexpectedMethodCallError struct {
-	mockName   MockName
-	methodName MethodName
+	mockName      MockName
+	methodName    MethodName
+	registrations []RegistrationInfo
 }
 
 func (err *UnexpectedMethodCallError) MockName() MockName {
@@ -33,22 +45,30 @@ func (err *UnexpectedMethodCallError) MethodName() MethodName {
 	return err.methodName
 }
 
+// Registrations returns an information about the registered functions at the
+// moment of the call.
+func (err *UnexpectedMethodCallError) Registrations() []RegistrationInfo {
+	return err.registrations
+}
+
 func (err *UnexpectedMethodCallError) Error() string {
-	return fmt.Sprintf("unexpected %s.%s() method call", err.mockName,
-		err.methodName)
+	return fmt.Sprintf("unexpected %s.%s() method call%s", err.mockName,
+		err.methodName, formatRegistrations(err.registrations))
 }
 
 // -----------------------------------------------------------------------------
 // NewUnknownMethodCallError creates new UnknownMethodCallError.
 func NewUnknownMethodCallError(mockName MockName,
 	methodName MethodName) *UnknownMethodCallError {
-	return &UnknownMethodCallError{mockName, methodName}
+	return &UnknownMethodCallError{mockName, methodName, nil}
 }
 
-// UnknownMethodCallError happens during an unregistered method call.
+// UnknownMethodCallError happens during an unregistered method call. It lists
+// registrations of the other mock methods.
 type UnknownMethodCallError struct {
-	mockName   MockName
-	methodName MethodName
+	mockName      MockName
+	methodName    MethodName
+	registrations []RegistrationInfo
 }
 
 func (err *UnknownMethodCallError) MockName() MockName {
@@ -59,7 +79,194 @@ func (err *UnknownMethodCallError) MethodName() MethodName {
 	return err.methodName
 }
 
+// Registrations returns an information about the registered functions at the
+// moment of the call.
+func (err *UnknownMethodCallError) Registrations() []RegistrationInfo {
+	return err.registrations
+}
+
 func (err *UnknownMethodCallError) Error() string {
-	return fmt.Sprintf("unknown %s.%s() method call", err.mockName,
-		err.methodName)
+	return fmt.Sprintf("unknown %s.%s() method call%s", err.mockName,
+		err.methodName, formatRegistrations(err.registrations))
+}
+
+// -----------------------------------------------------------------------------
+// NewArgumentsMismatchError creates new ArgumentsMismatchError.
+func NewArgumentsMismatchError(mockName MockName, methodName MethodName,
+	cause *MismatchError) *ArgumentsMismatchError {
+	return &ArgumentsMismatchError{mockName, methodName, cause}
+}
+
+// ArgumentsMismatchError happens during a method call, when none of the
+// registered method calls accepts the arguments.
+type ArgumentsMismatchError struct {
+	mockName   MockName
+	methodName MethodName
+	cause      *MismatchError
+}
+
+func (err *ArgumentsMismatchError) MockName() MockName {
+	return err.mockName
+}
+
+func (err *ArgumentsMismatchError) MethodName() MethodName {
+	return err.methodName
+}
+
+// Args returns the actual arguments of the method call.
+func (err *ArgumentsMismatchError) Args() []interface{} {
+	return err.cause.Args
+}
+
+func (err *ArgumentsMismatchError) Unwrap() error {
+	return err.cause
+}
+
+func (err *ArgumentsMismatchError) Error() string {
+	return fmt.Sprintf("unexpected %s.%s() method call %v", err.mockName,
+		err.methodName, err.cause)
+}
+
+// -----------------------------------------------------------------------------
+// NewArityMismatchError creates new ArityMismatchError.
+func NewArityMismatchError(mockName MockName, methodName MethodName,
+	matchers, params int) *ArityMismatchError {
+	return &ArityMismatchError{mockName, methodName, matchers, params}
+}
+
+// ArityMismatchError happens during the registration of a function, when the
+// number of the argument matchers differs from the number of its params.
+type ArityMismatchError struct {
+	mockName   MockName
+	methodName MethodName
+	matchers   int
+	params     int
+}
+
+func (err *ArityMismatchError) MockName() MockName {
+	return err.mockName
+}
+
+func (err *ArityMismatchError) MethodName() MethodName {
+	return err.methodName
+}
+
+// Matchers returns the number of the registered matchers.
+func (err *ArityMismatchError) Matchers() int {
+	return err.matchers
+}
+
+// Params returns the number of the function params.
+func (err *ArityMismatchError) Params() int {
+	return err.params
+}
+
+func (err *ArityMismatchError) Error() string {
+	return fmt.Sprintf("%s.%s() method registered with %v matchers for %v params",
+		err.mockName, err.methodName, err.matchers, err.params)
+}
+
+// -----------------------------------------------------------------------------
+// NewWaitTimeoutError creates new WaitTimeoutError.
+func NewWaitTimeoutError(mockName MockName, info []MethodCallsInfo,
+	cause error) *WaitTimeoutError {
+	return &WaitTimeoutError{mockName, info, cause}
+}
+
+// WaitTimeoutError happens when the context is done before the awaited method
+// calls have been completed.
+type WaitTimeoutError struct {
+	mockName MockName
+	info     []MethodCallsInfo
+	cause    error
+}
+
+func (err *WaitTimeoutError) MockName() MockName {
+	return err.mockName
+}
+
+// CallsInfo returns an information about the method calls at the moment of
+// the timeout.
+func (err *WaitTimeoutError) CallsInfo() []MethodCallsInfo {
+	return err.info
+}
+
+func (err *WaitTimeoutError) Unwrap() error {
+	return err.cause
+}
+
+func (err *WaitTimeoutError) Error() string {
+	strs := make([]string, len(err.info))
+	for i := 0; i < len(err.info); i++ {
+		strs[i] = err.info[i].String()
+	}
+	return fmt.Sprintf("waiting for %s method calls: %v [%s]", err.mockName,
+		err.cause, strings.Join(strs, "; "))
+}
+
+// -----------------------------------------------------------------------------
+// NewUndefinedMethodError creates new UndefinedMethodError.
+func NewUndefinedMethodError(mockName MockName, methodName MethodName,
+	iface reflect.Type) *UndefinedMethodError {
+	return &UndefinedMethodError{mockName, methodName, iface}
+}
+
+// UndefinedMethodError happens during the registration of a method, which is
+// not defined by the interface of the mock.
+type UndefinedMethodError struct {
+	mockName   MockName
+	methodName MethodName
+	iface      reflect.Type
+}
+
+func (err *UndefinedMethodError) MockName() MockName {
+	return err.mockName
+}
+
+func (err *UndefinedMethodError) MethodName() MethodName {
+	return err.methodName
+}
+
+func (err *UndefinedMethodError) Error() string {
+	return fmt.Sprintf("%s.%s() method is not defined by the %v interface",
+		err.mockName, err.methodName, err.iface)
+}
+
+// -----------------------------------------------------------------------------
+// NewSignatureMismatchError creates new SignatureMismatchError.
+func NewSignatureMismatchError(mockName MockName, methodName MethodName,
+	want, actual reflect.Type) *SignatureMismatchError {
+	return &SignatureMismatchError{mockName, methodName, want, actual}
+}
+
+// SignatureMismatchError happens during the registration of a function, whose
+// type differs from the method signature.
+type SignatureMismatchError struct {
+	mockName   MockName
+	methodName MethodName
+	want       reflect.Type
+	actual     reflect.Type
+}
+
+func (err *SignatureMismatchError) MockName() MockName {
+	return err.mockName
+}
+
+func (err *SignatureMismatchError) MethodName() MethodName {
+	return err.methodName
+}
+
+// Want returns the expected function type.
+func (err *SignatureMismatchError) Want() reflect.Type {
+	return err.want
+}
+
+// Actual returns the type of the registered function.
+func (err *SignatureMismatchError) Actual() reflect.Type {
+	return err.actual
+}
+
+func (err *SignatureMismatchError) Error() string {
+	return fmt.Sprintf("%s.%s() method registered with %v function, want %v",
+		err.mockName, err.methodName, err.actual, err.want)
 }
@@ -0,0 +1,42 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// CallRecord holds an information about a single method call.
+type CallRecord struct {
+	MethodName MethodName
+	Args       []interface{}
+	Results    []interface{}
+	Index      int // Index of the call among all calls of the method.
+	Start      time.Time
+	End        time.Time
+	// ID of the goroutine, that made the call, if the mock records them, see
+	// Mock.RecordGoroutines.
+	Goroutine uint64
+	Delegated bool // If true, the call was delegated to a real object.
+	// If true, the function panicked or called runtime.Goexit, like
+	// t.FailNow does, and there are no Results.
+	Aborted bool
+	Panic   interface{} // Value of the panic, if any.
+}
+
+// Duration returns the duration of the call.
+func (record CallRecord) Duration() time.Duration {
+	return record.End.Sub(record.Start)
+}
+
+func (record CallRecord) String() string {
+	var goroutine string
+	if record.Goroutine != 0 {
+		goroutine = fmt.Sprintf(", goroutine %v", record.Goroutine)
+	}
+	return fmt.Sprintf("#%v %v%v -> %v%v, took %v", record.Index,
+		record.MethodName,
+		formatArgs(record.Args),
+		formatArgs(record.Results),
+		goroutine,
+		record.Duration())
+}
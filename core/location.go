@@ -0,0 +1,60 @@
+package core
+
+import (
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// helpers holds names of the functions marked by Helper.
+var helpers sync.Map
+
+// These prefixes of the Mock and TypedMethod methods names, as they are
+// reported by the runtime, are skipped.
+var (
+	mockMethodPrefix        = reflect.TypeOf(Mock{}).PkgPath() + ".(*Mock)."
+	typedMethodMethodPrefix = reflect.TypeOf(Mock{}).PkgPath() +
+		".(*TypedMethod["
+)
+
+// Helper marks the calling function as a helper function. Like with the
+// testing.T.Helper, helper functions are skipped when the location of a
+// registration is captured. Generated mocks call it in their Register
+// methods.
+func Helper() {
+	pcs := make([]uintptr, 1)
+	if runtime.Callers(2, pcs) == 0 {
+		return
+	}
+	frame, _ := runtime.CallersFrames(pcs).Next()
+	helpers.Store(frame.Function, struct{}{})
+}
+
+// callerLocation returns the "file:line" location of the first caller, which
+// is neither a Mock method nor a helper function.
+func callerLocation() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !skipFrame(frame) {
+			return filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+func skipFrame(frame runtime.Frame) bool {
+	if strings.HasPrefix(frame.Function, mockMethodPrefix) ||
+		strings.HasPrefix(frame.Function, typedMethodMethodPrefix) ||
+		frame.File == "<autogenerated>" {
+		return true
+	}
+	_, pst := helpers.Load(frame.Function)
+	return pst
+}
@@ -0,0 +1,187 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Matcher checks a single method call argument. Matchers are used to guard
+// registered method calls, see Mock.RegisterMatch.
+type Matcher interface {
+	// Match returns true if the argument is accepted.
+	Match(v interface{}) bool
+	// String describes the matcher in error messages.
+	String() string
+}
+
+// Eq creates a Matcher that accepts an argument equal to want, compared with
+// the == operator. Arguments of an incomparable type are never accepted.
+func Eq(want interface{}) Matcher {
+	return eqMatcher{want}
+}
+
+// Any creates a Matcher that accepts any argument.
+func Any() Matcher {
+	return anyMatcher{}
+}
+
+// DeepEqual creates a Matcher that accepts an argument deeply equal to want,
+// see reflect.DeepEqual.
+func DeepEqual(want interface{}) Matcher {
+	return deepEqualMatcher{want}
+}
+
+// Len creates a Matcher that accepts a string, slice, array, map or channel
+// argument of length n.
+func Len(n int) Matcher {
+	return lenMatcher{n}
+}
+
+// Regexp creates a Matcher that accepts a string or []byte argument that
+// matches the pattern. Panics if the pattern can't be compiled.
+func Regexp(pattern string) Matcher {
+	return regexpMatcher{regexp.MustCompile(pattern)}
+}
+
+// Contains creates a Matcher that accepts a string argument with the elem
+// substring, a slice or array argument with the elem element, or a map
+// argument with the elem key.
+func Contains(elem interface{}) Matcher {
+	return containsMatcher{elem}
+}
+
+// Predicate creates a Matcher from a custom function. The desc is used to
+// describe the matcher in error messages.
+func Predicate(desc string, fn func(v interface{}) bool) Matcher {
+	return predicateMatcher{desc, fn}
+}
+
+// -----------------------------------------------------------------------------
+type eqMatcher struct {
+	want interface{}
+}
+
+func (matcher eqMatcher) Match(v interface{}) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+		}
+	}()
+	return v == matcher.want
+}
+
+func (matcher eqMatcher) String() string {
+	return fmt.Sprintf("Eq(%#v)", matcher.want)
+}
+
+// -----------------------------------------------------------------------------
+type anyMatcher struct{}
+
+func (matcher anyMatcher) Match(v interface{}) bool {
+	return true
+}
+
+func (matcher anyMatcher) String() string {
+	return "Any()"
+}
+
+// -----------------------------------------------------------------------------
+type deepEqualMatcher struct {
+	want interface{}
+}
+
+func (matcher deepEqualMatcher) Match(v interface{}) bool {
+	return reflect.DeepEqual(v, matcher.want)
+}
+
+func (matcher deepEqualMatcher) String() string {
+	return fmt.Sprintf("DeepEqual(%#v)", matcher.want)
+}
+
+// -----------------------------------------------------------------------------
+type lenMatcher struct {
+	n int
+}
+
+func (matcher lenMatcher) Match(v interface{}) bool {
+	val := reflect.ValueOf(v)
+	switch val.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map,
+		reflect.Chan:
+		return val.Len() == matcher.n
+	default:
+		return false
+	}
+}
+
+func (matcher lenMatcher) String() string {
+	return fmt.Sprintf("Len(%v)", matcher.n)
+}
+
+// -----------------------------------------------------------------------------
+type regexpMatcher struct {
+	re *regexp.Regexp
+}
+
+func (matcher regexpMatcher) Match(v interface{}) bool {
+	switch s := v.(type) {
+	case string:
+		return matcher.re.MatchString(s)
+	case []byte:
+		return matcher.re.Match(s)
+	default:
+		return false
+	}
+}
+
+func (matcher regexpMatcher) String() string {
+	return fmt.Sprintf("Regexp(%q)", matcher.re.String())
+}
+
+// -----------------------------------------------------------------------------
+type containsMatcher struct {
+	elem interface{}
+}
+
+func (matcher containsMatcher) Match(v interface{}) bool {
+	if s, ok := v.(string); ok {
+		substr, ok := matcher.elem.(string)
+		return ok && strings.Contains(s, substr)
+	}
+	val := reflect.ValueOf(v)
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			if reflect.DeepEqual(val.Index(i).Interface(), matcher.elem) {
+				return true
+			}
+		}
+	case reflect.Map:
+		for _, key := range val.MapKeys() {
+			if reflect.DeepEqual(key.Interface(), matcher.elem) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (matcher containsMatcher) String() string {
+	return fmt.Sprintf("Contains(%#v)", matcher.elem)
+}
+
+// -----------------------------------------------------------------------------
+type predicateMatcher struct {
+	desc string
+	fn   func(v interface{}) bool
+}
+
+func (matcher predicateMatcher) Match(v interface{}) bool {
+	return matcher.fn(v)
+}
+
+func (matcher predicateMatcher) String() string {
+	return matcher.desc
+}
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestMatchers(t *testing.T) {
+	cases := []struct {
+		name    string
+		matcher Matcher
+		v       interface{}
+		want    bool
+	}{
+		{"Eq", Eq(1), 1, true},
+		{"Eq mismatch", Eq(1), 2, false},
+		{"Eq incomparable", Eq([]int{1}), []int{1}, false},
+		{"Any", Any(), nil, true},
+		{"DeepEqual", DeepEqual([]int{1, 2}), []int{1, 2}, true},
+		{"DeepEqual mismatch", DeepEqual([]int{1, 2}), []int{1}, false},
+		{"Len string", Len(3), "abc", true},
+		{"Len slice", Len(2), []byte{1, 2}, true},
+		{"Len map", Len(1), map[int]int{1: 1}, true},
+		{"Len mismatch", Len(1), []byte{1, 2}, false},
+		{"Len int", Len(1), 1, false},
+		{"Regexp string", Regexp("^a.c$"), "abc", true},
+		{"Regexp bytes", Regexp("^a.c$"), []byte("abc"), true},
+		{"Regexp mismatch", Regexp("^a.c$"), "abcd", false},
+		{"Regexp int", Regexp("1"), 1, false},
+		{"Contains string", Contains("bc"), "abcd", true},
+		{"Contains slice", Contains(2), []int{1, 2}, true},
+		{"Contains map", Contains("k"), map[string]int{"k": 1}, true},
+		{"Contains mismatch", Contains(3), []int{1, 2}, false},
+		{"Predicate", Predicate("positive", func(v interface{}) bool {
+			return v.(int) > 0
+		}), 1, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if ok := c.matcher.Match(c.v); ok != c.want {
+				t.Errorf("unexpected %v.Match(%v) result, want '%v' actual '%v'",
+					c.matcher, c.v, c.want, ok)
+			}
+		})
+	}
+}
@@ -3,91 +3,399 @@ package core
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
+	"time"
 )
 
 // MethodName is a type for a method name.
 type MethodName string
 
 // -----------------------------------------------------------------------------
-// MethodCallsInfo holds an information about method calls.
+// MethodCallsInfo holds an information about method calls. The expected
+// number of calls is in the range from ExpectedCalls to ExpectedMaxCalls.
 type MethodCallsInfo struct {
-	MockName      MockName
-	MethodName    MethodName
-	ExpectedCalls int
-	ActualCalls   int
+	MockName         MockName
+	MethodName       MethodName
+	ExpectedCalls    int
+	ActualCalls      int
+	ExpectedMaxCalls int // If equal to Unlimited, there is no upper bound.
+	Registrations    []RegistrationInfo
 }
 
 func (info MethodCallsInfo) String() string {
-	return fmt.Sprintf("%v.%v() calls count: want %v, actual %v", info.MockName,
+	return fmt.Sprintf("%v.%v() calls count: want %v, actual %v%v",
+		info.MockName,
 		info.MethodName,
-		info.ExpectedCalls,
+		formatRange(info.ExpectedCalls, info.ExpectedMaxCalls),
+		info.ActualCalls,
+		formatRegistrations(info.Registrations))
+}
+
+// -----------------------------------------------------------------------------
+// RegistrationInfo holds an information about a registered function.
+type RegistrationInfo struct {
+	MethodName  MethodName
+	Location    string // The "file:line" location of the registration.
+	Times       Times
+	ActualCalls int
+}
+
+func (info RegistrationInfo) String() string {
+	return fmt.Sprintf("%v() registered at %v: want %v, actual %v",
+		info.MethodName,
+		info.Location,
+		info.Times,
 		info.ActualCalls)
 }
 
 // -----------------------------------------------------------------------------
 // NewMethod creates new Method.
 func NewMethod() *Method {
-	return &Method{fns: []reflect.Value{}, mu: sync.Mutex{}}
+	return &Method{calls: []*methodCall{}, mu: sync.Mutex{}}
 }
 
 // Method represents a struct method.
 type Method struct {
 	callsCount int
-	fns        []reflect.Value
+	completed  int
+	calls      []*methodCall
 	mu         sync.Mutex
 }
 
 // AddMethodCall to the method. Each method call should be a function.
 func (method *Method) AddMethodCall(fn Func) {
+	method.AddMatchedMethodCall(fn, nil)
+}
+
+// AddMatchedMethodCall adds a method call guarded by the argument matchers.
+// Such method call accepts only arguments that satisfy all matchers, one
+// matcher per argument. If matchers is nil, any arguments are accepted. If the
+// number of arguments differs from the number of matchers, none are accepted.
+func (method *Method) AddMatchedMethodCall(fn Func, matchers []Matcher) {
+	method.AddRegistration(fn, matchers, Exactly(1), "")
+}
+
+// AddRegistration adds a function, which accepts arguments like
+// AddMatchedMethodCall and is expected to be called the specified number of
+// times. The location is the "file:line" location of the registration, it is
+// used in reports.
+func (method *Method) AddRegistration(fn Func, matchers []Matcher,
+	times Times, location string) {
 	method.mu.Lock()
 	defer method.mu.Unlock()
-	method.fns = append(method.fns, reflect.ValueOf(fn))
+	method.calls = append(method.calls, &methodCall{
+		fn:       reflect.ValueOf(fn),
+		matchers: matchers,
+		times:    times,
+		location: location,
+	})
 }
 
-// Call calls a method once. With help of reflection calls a function,
-// registered as a method call, with the given params.
-// reflect.Value param is passed to the corresponding function as is.
+// Registrations returns an information about all added functions.
+func (method *Method) Registrations(methodName MethodName) []RegistrationInfo {
+	method.mu.Lock()
+	defer method.mu.Unlock()
+	return method.registrations(methodName)
+}
+
+// Call calls a method once. With help of reflection calls the first not yet
+// exhausted method call, which accepts the given params.
+// A param wrapped with Wrap is passed to the corresponding function as the
+// wrapped value, any other one, including reflect.Value, is passed as is.
 // If all registered method calls have already been made, an ErrUnexpectedCall
-// error is returned.
+// error is returned. If none of the remaining method calls accepts the params,
+// a *MismatchError is returned.
 // Threadsafe.
 func (method *Method) Call(params []interface{}) ([]interface{}, error) {
-	var fn reflect.Value
+	record, err := method.call(params, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	return record.Results, nil
+}
+
+// CheckCalls checks method calls. If any of the added method calls was
+// called an unexpected number of times, it returns ok == false.
+func (method *Method) CheckCalls(mockName MockName, methodName MethodName) (
+	info MethodCallsInfo, ok bool) {
 	method.mu.Lock()
-	if len(method.fns) < method.callsCount+1 {
+	defer method.mu.Unlock()
+	return checkRegistrations(mockName, methodName, method.callsCount,
+		method.registrations(methodName))
+}
+
+// CompletedCalls returns the number of method calls, whose functions have
+// already returned.
+func (method *Method) CompletedCalls() int {
+	method.mu.Lock()
+	defer method.mu.Unlock()
+	return method.completed
+}
+
+// done checks if all added method calls were called the expected number of
+// times and have been completed.
+func (method *Method) done() bool {
+	method.mu.Lock()
+	defer method.mu.Unlock()
+	if method.completed != method.callsCount {
+		return false
+	}
+	for _, call := range method.calls {
+		if !call.times.Satisfied(call.count) {
+			return false
+		}
+	}
+	return true
+}
+
+// call performs like Call, but returns the whole record of the call, see
+// invoke.
+func (method *Method) call(params []interface{}, goroutine bool,
+	done func(record CallRecord)) (record CallRecord, err error) {
+	var (
+		vals = toReflectValues(params)
+		args = fromReflectValues(vals)
+	)
+	method.mu.Lock()
+	call, err := method.nextCall(args)
+	if err != nil {
 		method.mu.Unlock()
-		return nil, ErrUnexpectedCall
+		return
 	}
-	fn = method.fns[method.callsCount]
+	call.count++
+	record.Index = method.callsCount
 	method.increaseCallsCount()
 	method.mu.Unlock()
-
-	result := fn.Call(toReflectValues(params))
-	return fromReflectValues(result), nil
+	return method.invoke(call.fn, vals, args, record, goroutine, done), nil
 }
 
-// CheckCalls checks method calls. If the number of method calls added is not
-// equal to the number of calls, it returns ok == false.
-func (method *Method) CheckCalls(mockName MockName, methodName MethodName) (
-	info MethodCallsInfo, ok bool) {
+// delegate calls fn, which is not a registered method call, with the params.
+// Such call is counted and recorded like any other call.
+func (method *Method) delegate(fn reflect.Value, params []interface{},
+	goroutine bool, done func(record CallRecord)) CallRecord {
+	var (
+		vals = toReflectValues(params)
+		args = fromReflectValues(vals)
+	)
 	method.mu.Lock()
-	defer method.mu.Unlock()
-	if len(method.fns) != method.callsCount {
-		return MethodCallsInfo{mockName, methodName, len(method.fns),
-			method.callsCount}, false
+	record := CallRecord{Index: method.callsCount, Delegated: true}
+	method.increaseCallsCount()
+	method.mu.Unlock()
+	return method.invoke(fn, vals, args, record, goroutine, done)
+}
+
+// invoke calls fn. If goroutine is true, the ID of the calling goroutine is
+// recorded. The call is completed and passed to done, if it's not nil, even if
+// fn panics or calls runtime.Goexit, like t.FailNow does. In this case the
+// record is Aborted, and the panic is propagated.
+func (method *Method) invoke(fn reflect.Value, vals []reflect.Value,
+	args []interface{}, record CallRecord, goroutine bool,
+	done func(record CallRecord)) CallRecord {
+	record.Args = args
+	if goroutine {
+		record.Goroutine = goroutineID()
 	}
-	return MethodCallsInfo{}, true
+	record.Start = time.Now()
+	record.Aborted = true
+	defer func() {
+		var r interface{}
+		if record.Aborted {
+			record.End = time.Now()
+			record.Panic = recover()
+			r = record.Panic
+		}
+		method.mu.Lock()
+		method.completed++
+		method.mu.Unlock()
+		if done != nil {
+			done(record)
+		}
+		if r != nil {
+			panic(r)
+		}
+	}()
+	var result []reflect.Value
+	if fn.Type().IsVariadic() {
+		// The variadic params are passed as a slice.
+		result = fn.CallSlice(vals)
+	} else {
+		result = fn.Call(vals)
+	}
+	record.End = time.Now()
+	record.Results = fromReflectValues(result)
+	record.Aborted = false
+	return record
+}
+
+func (method *Method) nextCall(args []interface{}) (call *methodCall,
+	err error) {
+	var (
+		nearest      = -1
+		nearestScore = NoArgument - 1
+		remain       bool
+	)
+	for i := 0; i < len(method.calls); i++ {
+		if method.calls[i].times.Exhausted(method.calls[i].count) {
+			continue
+		}
+		remain = true
+		score, ok := method.calls[i].match(args)
+		if ok {
+			return method.calls[i], nil
+		}
+		if score > nearestScore {
+			nearest, nearestScore = i, score
+		}
+	}
+	if !remain {
+		return nil, ErrUnexpectedCall
+	}
+	return nil, &MismatchError{
+		Args:     args,
+		Nearest:  nearest,
+		Matchers: method.calls[nearest].matchers,
+		Location: method.calls[nearest].location,
+		Argument: nearestScore,
+	}
+}
+
+func (method *Method) registrations(
+	methodName MethodName) []RegistrationInfo {
+	infos := make([]RegistrationInfo, len(method.calls))
+	for i, call := range method.calls {
+		infos[i] = RegistrationInfo{
+			MethodName:  methodName,
+			Location:    call.location,
+			Times:       call.times,
+			ActualCalls: call.count,
+		}
+	}
+	return infos
 }
 
 func (method *Method) increaseCallsCount() {
 	method.callsCount++
 }
 
+// -----------------------------------------------------------------------------
+// NoArgument is the MismatchError.Argument, when the number of arguments
+// differs from the number of matchers.
+const NoArgument = -1
+
+// MismatchError is returned by Method.Call when none of the remaining method
+// calls accepts the arguments.
+type MismatchError struct {
+	Args     []interface{} // Actual arguments.
+	Nearest  int           // Index of the nearest mismatching method call.
+	Matchers []Matcher     // Matchers of the nearest method call.
+	Location string        // Location of the nearest method call.
+	Argument int           // Index of the first mismatched argument or NoArgument.
+}
+
+func (err *MismatchError) Error() string {
+	if err.Argument == NoArgument {
+		return fmt.Sprintf("arguments %v, nearest registration #%v %v at %v "+
+			"expects %v arguments", formatArgs(err.Args), err.Nearest,
+			formatMatchers(err.Matchers), err.Location, len(err.Matchers))
+	}
+	return fmt.Sprintf("arguments %v, nearest registration #%v %v at %v "+
+		"mismatches argument %v", formatArgs(err.Args), err.Nearest,
+		formatMatchers(err.Matchers), err.Location, err.Argument)
+}
+
+// -----------------------------------------------------------------------------
+type methodCall struct {
+	fn       reflect.Value
+	matchers []Matcher
+	times    Times
+	count    int
+	location string
+}
+
+// match checks args. If the method call doesn't accept them, returns the
+// number of leading accepted arguments, or NoArgument if the number of
+// arguments differs from the number of matchers.
+func (call *methodCall) match(args []interface{}) (score int, ok bool) {
+	if call.matchers == nil {
+		return 0, true
+	}
+	if len(call.matchers) != len(args) {
+		return NoArgument, false
+	}
+	for score = 0; score < len(call.matchers); score++ {
+		if !call.matchers[score].Match(args[score]) {
+			return
+		}
+	}
+	return score, true
+}
+
+// checkRegistrations checks if each registered function was called the
+// expected number of times. actual is the total number of the method calls.
+func checkRegistrations(mockName MockName, methodName MethodName, actual int,
+	regs []RegistrationInfo) (info MethodCallsInfo, ok bool) {
+	ok = true
+	info = MethodCallsInfo{MockName: mockName, MethodName: methodName,
+		ActualCalls: actual, Registrations: regs}
+	for _, reg := range regs {
+		if !reg.Times.Satisfied(reg.ActualCalls) {
+			ok = false
+		}
+		info.ExpectedCalls += reg.Times.Min
+		if reg.Times.Max == Unlimited ||
+			info.ExpectedMaxCalls == Unlimited {
+			info.ExpectedMaxCalls = Unlimited
+		} else {
+			info.ExpectedMaxCalls += reg.Times.Max
+		}
+	}
+	if ok {
+		return MethodCallsInfo{}, true
+	}
+	return
+}
+
+func formatRegistrations(infos []RegistrationInfo) (str string) {
+	for _, info := range infos {
+		str += "\n\t" + info.String()
+	}
+	return
+}
+
+func formatArgs(args []interface{}) string {
+	strs := make([]string, len(args))
+	for i := 0; i < len(args); i++ {
+		strs[i] = fmt.Sprintf("%#v", args[i])
+	}
+	return "(" + strings.Join(strs, ", ") + ")"
+}
+
+func formatMatchers(matchers []Matcher) string {
+	strs := make([]string, len(matchers))
+	for i := 0; i < len(matchers); i++ {
+		strs[i] = matchers[i].String()
+	}
+	return "(" + strings.Join(strs, ", ") + ")"
+}
+
+// Wrap wraps the param of the interface or type parameter type, so that it
+// keeps its static type, when is passed to the Mock.Call. Otherwise a nil
+// interface can't be passed to the registered function.
+func Wrap[T any](param T) interface{} {
+	return wrapped{reflect.ValueOf(&param).Elem()}
+}
+
+// wrapped is a param wrapped by Wrap.
+type wrapped struct {
+	val reflect.Value
+}
+
 func toReflectValues(vals []interface{}) []reflect.Value {
 	rvals := make([]reflect.Value, len(vals))
 	for i := 0; i < len(vals); i++ {
-		if rval, ok := vals[i].(reflect.Value); ok {
-			rvals[i] = rval
+		if w, ok := vals[i].(wrapped); ok {
+			rvals[i] = w.val
 		} else {
 			rvals[i] = reflect.ValueOf(vals[i])
 		}
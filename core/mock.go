@@ -1,9 +1,13 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
+	"sync/atomic"
+	"testing"
 )
 
 // MockName is a type for a mock name.
@@ -17,22 +21,68 @@ func New(name MockName) *Mock {
 	return &Mock{name: name}
 }
 
+// NewWrapping creates new Mock, which delegates calls of the unregistered
+// methods, or calls past the registered ones, to the real object.
+// Delegated calls are counted and recorded in the history as well.
+func NewWrapping(name MockName, real interface{}) *Mock {
+	return &Mock{name: name, real: reflect.ValueOf(real)}
+}
+
+// NewWithT creates new Mock, which reports failures to t. Also it registers
+// a t.Cleanup function, which fails the test if not all registered method
+// calls were made.
+// Should be called from the test goroutine.
+func NewWithT(name MockName, t testing.TB) *Mock {
+	mock := &Mock{name: name, t: t, tID: goroutineID()}
+	checkCallsAtCleanup(t, mock.CheckCalls)
+	return mock
+}
+
 // Mock helps you to mock interfaces.
 type Mock struct {
-	name MockName
-	m    sync.Map
+	name    MockName
+	m       sync.Map
+	t       testing.TB
+	tID     uint64
+	history []CallRecord
+	hmu     sync.Mutex
+	changed chan struct{}
+	real    reflect.Value
+	iface   reflect.Type
+	// If true, goroutine IDs of the calls are recorded, see RecordGoroutines.
+	goroutines atomic.Bool
+}
+
+// ForInterface sets the interface type implemented by the mock. After that
+// Register methods panic with UndefinedMethodError if the interface doesn't
+// define the method, or with SignatureMismatchError if the function type
+// differs from the method signature.
+// Should be called before any registration, for example:
+// New("Reader").ForInterface(reflect.TypeOf((*io.Reader)(nil)).Elem())
+// Panics if tp is not an interface type.
+func (mock *Mock) ForInterface(tp reflect.Type) *Mock {
+	if tp.Kind() != reflect.Interface {
+		panic(ErrNotInterface)
+	}
+	mock.iface = tp
+	return mock
+}
+
+// RecordGoroutines makes the mock record the ID of the goroutine, that made
+// the call, in CallRecord.Goroutine. It's off by default, because getting the
+// ID takes a runtime.Stack call.
+func (mock *Mock) RecordGoroutines() *Mock {
+	mock.goroutines.Store(true)
+	return mock
 }
 
 // Register registers a method. A function is registered as one method call.
 // You could chain Register calls:
 // mock.Register("Handle", ...).Register("Handle", ...)
+// The location of the registration is captured for reports, Mock methods and
+// functions marked by Helper are skipped.
 func (mock *Mock) Register(name MethodName, fn Func) *Mock {
-	if !isFunc(fn) {
-		panic(ErrNotFunction)
-	}
-	method, _ := mock.m.LoadOrStore(name, NewMethod())
-	method.(*Method).AddMethodCall(fn)
-	return mock
+	return mock.RegisterMatchTimes(name, Exactly(1), nil, fn)
 }
 
 // RegisterN registers a method. A function is registered as several method
@@ -44,6 +94,64 @@ func (mock *Mock) RegisterN(name MethodName, n int, fn Func) *Mock {
 	return mock
 }
 
+// RegisterMatch registers a method call guarded by the argument matchers, one
+// matcher per argument. nil matchers accept any arguments, like Register.
+// A method call is dispatched to the first registered function, which has not
+// been called yet and whose matchers accept the arguments. You could chain
+// RegisterMatch calls:
+// mock.RegisterMatch("Handle", []Matcher{Eq(1)}, ...).
+// RegisterMatch("Handle", []Matcher{Any()}, ...)
+// Panics with ArityMismatchError if the number of matchers differs from the
+// number of the function params.
+func (mock *Mock) RegisterMatch(name MethodName, matchers []Matcher,
+	fn Func) *Mock {
+	return mock.RegisterMatchTimes(name, Exactly(1), matchers, fn)
+}
+
+// RegisterMatchN performs like RegisterMatch. A function is registered as
+// several method calls.
+func (mock *Mock) RegisterMatchN(name MethodName, n int, matchers []Matcher,
+	fn Func) *Mock {
+	for i := 0; i < n; i++ {
+		mock.RegisterMatch(name, matchers, fn)
+	}
+	return mock
+}
+
+// RegisterTimes registers a function, which is expected to be called the
+// specified number of times, for example:
+// mock.RegisterTimes("Handle", AtLeast(1), ...)
+// Until the function has been called times.Max times, all method calls are
+// dispatched to it, so the following registrations of the same method are
+// reached only after that.
+func (mock *Mock) RegisterTimes(name MethodName, times Times, fn Func) *Mock {
+	return mock.RegisterMatchTimes(name, times, nil, fn)
+}
+
+// RegisterMatchTimes performs like RegisterTimes, but the function accepts
+// only arguments that satisfy the matchers, see RegisterMatch.
+// If times are invalid, the registration fails with an error, that wraps
+// ErrInvalidTimes, see Fail.
+func (mock *Mock) RegisterMatchTimes(name MethodName, times Times,
+	matchers []Matcher, fn Func) *Mock {
+	if !isFunc(fn) {
+		panic(ErrNotFunction)
+	}
+	if err := times.Validate(); err != nil {
+		mock.Fail(fmt.Errorf("%v.%v() method: %w", mock.name, name, err))
+		return mock
+	}
+	if err := mock.validate(name, fn); err != nil {
+		panic(err)
+	}
+	if n := reflect.TypeOf(fn).NumIn(); matchers != nil && len(matchers) != n {
+		panic(NewArityMismatchError(mock.name, name, len(matchers), n))
+	}
+	method, _ := mock.m.LoadOrStore(name, NewMethod())
+	method.(*Method).AddRegistration(fn, matchers, times, callerLocation())
+	return mock
+}
+
 // Unregister unregisters a method.
 func (mock *Mock) Unregister(name MethodName) *Mock {
 	mock.m.Delete(name)
@@ -51,26 +159,129 @@ func (mock *Mock) Unregister(name MethodName) *Mock {
 }
 
 // Call calls a method with specified parameters. Uses reflection to execute
-// functions registered as method calls. Note that the reflect.Value parameters
-// are passed to these functions as is.
+// functions registered as method calls. Params of the interface types, which
+// may be nil, should be wrapped with Wrap. The variadic params of a method
+// should be passed as a single slice.
 // If no method was registered, UnknownMethodCallError is returned. If all
 // registered method calls have already been made, UnexpectedMethodCallError is
-// returned.
+// returned. If none of the remaining method calls accepts the params,
+// ArgumentsMismatchError is returned.
+// The mock created with NewWrapping delegates the first two cases to the real
+// object.
 func (mock *Mock) Call(name MethodName, params ...interface{}) (
 	[]interface{}, error) {
+	var (
+		goroutine = mock.goroutines.Load()
+		done      = mock.recorder(name)
+	)
 	method, pst := mock.m.Load(name)
 	if !pst {
-		return nil, NewUnknownMethodCallError(mock.name, name)
+		if fn, ok := mock.realMethod(name); ok {
+			method, _ = mock.m.LoadOrStore(name, NewMethod())
+			return method.(*Method).delegate(fn, params, goroutine, done).Results,
+				nil
+		}
+		return nil, &UnknownMethodCallError{mock.name, name,
+			mock.registrations()}
 	}
-	vals, err := method.(*Method).Call(params)
+	record, err := method.(*Method).call(params, goroutine, done)
 	if err != nil {
 		if err == ErrUnexpectedCall {
-			return nil, NewUnexpectedMethodCallError(mock.name, name)
+			if fn, ok := mock.realMethod(name); ok {
+				return method.(*Method).delegate(fn, params, goroutine, done).
+					Results, nil
+			}
+			return nil, &UnexpectedMethodCallError{mock.name, name,
+				method.(*Method).Registrations(name)}
+		} else if mErr, ok := err.(*MismatchError); ok {
+			return nil, NewArgumentsMismatchError(mock.name, name, mErr)
 		} else {
 			panic(fmt.Sprintf("unepxected '%v' err", err))
 		}
 	}
-	return vals, nil
+	return record.Results, nil
+}
+
+// Calls returns records of the completed calls of the method, in the order of
+// their completion.
+// Threadsafe.
+func (mock *Mock) Calls(name MethodName) []CallRecord {
+	mock.hmu.Lock()
+	defer mock.hmu.Unlock()
+	records := []CallRecord{}
+	for _, record := range mock.history {
+		if record.MethodName == name {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// History returns records of all completed method calls, in the order of
+// their completion. Records are kept, with the arguments and results, until
+// ResetHistory is called, so a long-running test, that makes many calls,
+// should reset the history from time to time.
+// Threadsafe.
+func (mock *Mock) History() []CallRecord {
+	mock.hmu.Lock()
+	defer mock.hmu.Unlock()
+	records := make([]CallRecord, len(mock.history))
+	copy(records, mock.history)
+	return records
+}
+
+// ResetHistory removes all records from the history. It doesn't affect the
+// registered method calls and their counters.
+// Threadsafe.
+func (mock *Mock) ResetHistory() {
+	mock.hmu.Lock()
+	mock.history = nil
+	mock.hmu.Unlock()
+}
+
+// Fail handles an error returned by the Call method. If the mock was created
+// with NewWithT, it calls t.Fatalf on the test goroutine and t.Errorf on any
+// other goroutine, where t.Fatalf is not allowed. Otherwise it panics.
+func (mock *Mock) Fail(err error) {
+	fail(mock.t, mock.tID, err)
+}
+
+// Wait blocks until all registered method calls have been made the expected
+// number of times and completed. A call that panics or calls runtime.Goexit is
+// completed too. If ctx is done earlier, WaitTimeoutError is returned.
+func (mock *Mock) Wait(ctx context.Context) error {
+	for {
+		changed := mock.changes()
+		if mock.done() {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return NewWaitTimeoutError(mock.name, mock.CheckCalls(), ctx.Err())
+		}
+	}
+}
+
+// WaitMethod blocks until at least n calls of the method have been completed.
+// If ctx is done earlier, WaitTimeoutError is returned.
+func (mock *Mock) WaitMethod(ctx context.Context, name MethodName,
+	n int) error {
+	for {
+		changed := mock.changes()
+		completed := mock.completedCalls(name)
+		if completed >= n {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			info := MethodCallsInfo{MockName: mock.name, MethodName: name,
+				ExpectedCalls: n, ActualCalls: completed, ExpectedMaxCalls: Unlimited}
+			return NewWaitTimeoutError(mock.name, []MethodCallsInfo{info},
+				ctx.Err())
+		}
+	}
 }
 
 // CheckCalls checks method calls. If all registered methods were called the
@@ -89,6 +300,116 @@ func (mock *Mock) CheckCalls() []MethodCallsInfo {
 	return arr
 }
 
+// recorder returns a function, that adds the record of the completed call of
+// the method to the history.
+func (mock *Mock) recorder(name MethodName) func(record CallRecord) {
+	return func(record CallRecord) {
+		record.MethodName = name
+		mock.hmu.Lock()
+		mock.history = append(mock.history, record)
+		if mock.changed != nil {
+			close(mock.changed)
+			mock.changed = nil
+		}
+		mock.hmu.Unlock()
+	}
+}
+
+// validate checks the name and the function against the interface of the mock,
+// if it was set.
+func (mock *Mock) validate(name MethodName, fn Func) error {
+	if mock.iface == nil {
+		return nil
+	}
+	method, pst := mock.iface.MethodByName(string(name))
+	if !pst {
+		return NewUndefinedMethodError(mock.name, name, mock.iface)
+	}
+	if tp := reflect.TypeOf(fn); tp != method.Type {
+		return NewSignatureMismatchError(mock.name, name, method.Type, tp)
+	}
+	return nil
+}
+
+// realMethod returns the method of the real object, if there is one.
+func (mock *Mock) realMethod(name MethodName) (fn reflect.Value, ok bool) {
+	if !mock.real.IsValid() {
+		return
+	}
+	fn = mock.real.MethodByName(string(name))
+	return fn, fn.IsValid()
+}
+
+// changes returns a channel, which is closed on the next completed method
+// call.
+func (mock *Mock) changes() <-chan struct{} {
+	mock.hmu.Lock()
+	defer mock.hmu.Unlock()
+	if mock.changed == nil {
+		mock.changed = make(chan struct{})
+	}
+	return mock.changed
+}
+
+// registrations returns an information about all registered functions,
+// sorted by method name.
+func (mock *Mock) registrations() []RegistrationInfo {
+	names := []string{}
+	mock.m.Range(func(key, value interface{}) bool {
+		names = append(names, string(key.(MethodName)))
+		return true
+	})
+	sort.Strings(names)
+	infos := []RegistrationInfo{}
+	for _, name := range names {
+		if method, pst := mock.m.Load(MethodName(name)); pst {
+			infos = append(infos,
+				method.(*Method).Registrations(MethodName(name))...)
+		}
+	}
+	return infos
+}
+
+func (mock *Mock) done() (done bool) {
+	done = true
+	mock.m.Range(func(key, value interface{}) bool {
+		done = value.(*Method).done()
+		return done
+	})
+	return
+}
+
+func (mock *Mock) completedCalls(name MethodName) int {
+	method, pst := mock.m.Load(name)
+	if !pst {
+		return 0
+	}
+	return method.(*Method).CompletedCalls()
+}
+
+// fail reports err to t, see Mock.Fail. tID is the ID of the test goroutine.
+func fail(t testing.TB, tID uint64, err error) {
+	if t == nil {
+		panic(err)
+	}
+	t.Helper()
+	if goroutineID() == tID {
+		t.Fatalf("%v", err)
+	}
+	t.Errorf("%v", err)
+}
+
+// checkCallsAtCleanup registers a t.Cleanup function, which fails the test if
+// checkCalls reports any problems.
+func checkCallsAtCleanup(t testing.TB, checkCalls func() []MethodCallsInfo) {
+	t.Cleanup(func() {
+		t.Helper()
+		for _, info := range checkCalls() {
+			t.Errorf("%v", info)
+		}
+	})
+}
+
 func isFunc(v interface{}) bool {
 	return reflect.TypeOf(v).Kind() == reflect.Func
 }
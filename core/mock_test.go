@@ -2,13 +2,65 @@ package core
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"fmt"
 	"io"
+	"path/filepath"
 	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 )
 
+// testingTB records failures instead of failing the test.
+type testingTB struct {
+	testing.TB
+	mu      sync.Mutex
+	errs    []string
+	fatals  []string
+	cleanup func()
+}
+
+func (t *testingTB) Helper() {}
+
+func (t *testingTB) Errorf(format string, args ...interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.errs = append(t.errs, fmt.Sprintf(format, args...))
+}
+
+func (t *testingTB) Fatalf(format string, args ...interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.fatals = append(t.fatals, fmt.Sprintf(format, args...))
+	panic(errFatal)
+}
+
+// FailNow stops the calling goroutine like testing.T.FailNow.
+func (t *testingTB) FailNow() {
+	t.mu.Lock()
+	t.fatals = append(t.fatals, "FailNow")
+	t.mu.Unlock()
+	runtime.Goexit()
+}
+
+func (t *testingTB) Cleanup(fn func()) {
+	t.cleanup = fn
+}
+
+var errFatal = errors.New("fatal")
+
+// nextLineLocation returns the location of the line following the call.
+func nextLineLocation() string {
+	_, file, line, _ := runtime.Caller(1)
+	return filepath.Base(file) + ":" + strconv.Itoa(line+1)
+}
+
+// -----------------------------------------------------------------------------
 func NewReaderMock() ReaderMock {
 	return ReaderMock{New("Reader")}
 }
@@ -20,6 +72,7 @@ type ReaderMock struct {
 
 func (reader ReaderMock) RegisterRead(
 	fn func(p []byte) (n int, err error)) ReaderMock {
+	Helper()
 	reader.Register("Read", fn)
 	return reader
 }
@@ -52,13 +105,7 @@ func (writer WriterToMock) RegisterWriteTo(fn func(w io.Writer) (n int64,
 }
 
 func (writer WriterToMock) WriteTo(w io.Writer) (n int64, err error) {
-	var wVal reflect.Value
-	if w == nil {
-		wVal = reflect.Zero(reflect.TypeOf((*io.Writer)(nil)).Elem())
-	} else {
-		wVal = reflect.ValueOf(w)
-	}
-	vals, err := writer.Call("WriteTo", wVal)
+	vals, err := writer.Call("WriteTo", Wrap(w))
 	if err != nil {
 		return
 	}
@@ -105,7 +152,7 @@ func TestMock(t *testing.T) {
 	})
 
 	t.Run("Unregister", func(t *testing.T) {
-		want := UnknownMethodCallError{"Reader", "Read"}
+		want := NewUnknownMethodCallError("Reader", "Read")
 		reader := NewReaderMock()
 		reader.RegisterRead(func(p []byte) (n int, err error) {
 			return 0, nil
@@ -118,44 +165,53 @@ func TestMock(t *testing.T) {
 	})
 
 	t.Run("Unknown method call", func(t *testing.T) {
-		want := UnknownMethodCallError{"Reader", "ReadN"}
 		reader := NewReaderMock()
+		location := nextLineLocation()
 		reader.RegisterRead(func(p []byte) (n int, err error) {
 			return 0, nil
 		})
+		want := "unknown Reader.ReadN() method call\n\tRead() registered at " +
+			location + ": want 1, actual 0"
 		result, err := reader.Call("ReadN", []byte{})
 		if result != nil {
 			t.Error("unexpected result")
 		}
-		if err.Error() != want.Error() {
-			t.Error("unexpected error")
+		if err.Error() != want {
+			t.Errorf("unexpected error, want '%v' actual '%v'", want, err)
 		}
-		if want.MockName() != "Reader" {
+		uErr := err.(*UnknownMethodCallError)
+		if uErr.MockName() != "Reader" {
 			t.Error("unexpected MockName")
 		}
-		if want.MethodName() != "ReadN" {
+		if uErr.MethodName() != "ReadN" {
 			t.Error("unexpected MethodName")
 		}
+		if len(uErr.Registrations()) != 1 {
+			t.Error("unexpected Registrations")
+		}
 	})
 
 	t.Run("Unexpected call", func(t *testing.T) {
-		want := UnexpectedMethodCallError{"Reader", "Read"}
 		reader := NewReaderMock()
+		location := nextLineLocation()
 		reader.RegisterRead(func(p []byte) (n int, err error) {
 			return 0, nil
 		})
+		want := "unexpected Reader.Read() method call\n\tRead() registered at " +
+			location + ": want 1, actual 1"
 		reader.Call("Read", []byte{})
 		result, err := reader.Call("Read", []byte{})
 		if result != nil {
 			t.Error("unexpected result")
 		}
-		if err.Error() != want.Error() {
-			t.Error("unexpected error")
+		if err.Error() != want {
+			t.Errorf("unexpected error, want '%v' actual '%v'", want, err)
 		}
-		if want.MockName() != "Reader" {
+		uErr := err.(*UnexpectedMethodCallError)
+		if uErr.MockName() != "Reader" {
 			t.Error("unexpected MockName")
 		}
-		if want.MethodName() != "Read" {
+		if uErr.MethodName() != "Read" {
 			t.Error("unexpected MethodName")
 		}
 	})
@@ -183,15 +239,18 @@ func TestMock(t *testing.T) {
 		want1 := 10
 		want2 := 20
 		wantNums := map[int]struct{}{want1: {}, want2: {}}
-		wantErr := UnexpectedMethodCallError{"Reader", "Read"}
-		wantErrs := map[string]struct{}{wantErr.Error(): {}}
 		reader := NewReaderMock()
+		location := nextLineLocation()
 		reader.RegisterRead(func(p []byte) (n int, err error) {
 			return want1, nil
 		})
+		wantErr := "unexpected Reader.Read() method call\n\tRead() registered at " +
+			location + ": want 1, actual 1\n\tRead() registered at " +
+			nextLineLocation() + ": want 1, actual 1"
 		reader.RegisterRead(func(p []byte) (n int, err error) {
 			return want2, nil
 		})
+		wantErrs := map[string]struct{}{wantErr: {}}
 		nums := make(chan int, 3)
 		errs := make(chan error, 3)
 		wg := &sync.WaitGroup{}
@@ -228,6 +287,577 @@ func TestMock(t *testing.T) {
 		}
 	})
 
+	t.Run("Argument matchers", func(t *testing.T) {
+		reader := NewReaderMock()
+		reader.RegisterMatch("Read", []Matcher{Len(2)},
+			func(p []byte) (n int, err error) {
+				return 2, nil
+			})
+		reader.RegisterMatch("Read", []Matcher{DeepEqual([]byte{1})},
+			func(p []byte) (n int, err error) {
+				return 1, nil
+			})
+		n, err := reader.Read([]byte{1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 1 {
+			t.Errorf("unexpected n, want '%v' actual '%v'", 1, n)
+		}
+		n, err = reader.Read([]byte{1, 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 2 {
+			t.Errorf("unexpected n, want '%v' actual '%v'", 2, n)
+		}
+		arr := reader.CheckCalls()
+		if len(arr) != 0 {
+			t.Error("unexpected CheckCalls result")
+		}
+	})
+
+	t.Run("Arguments mismatch", func(t *testing.T) {
+		reader := NewReaderMock()
+		location := nextLineLocation()
+		reader.RegisterMatch("Read", []Matcher{Len(3)},
+			func(p []byte) (n int, err error) {
+				return 0, nil
+			})
+		reader.RegisterMatch("Read", []Matcher{Len(1)},
+			func(p []byte) (n int, err error) {
+				return 0, nil
+			})
+		want := "unexpected Reader.Read() method call arguments ([]byte{0x1}), " +
+			"nearest registration #0 (Len(3)) at " + location +
+			" mismatches argument 0"
+		reader.Read([]byte{1})
+		_, err := reader.Read([]byte{1})
+		if err.Error() != want {
+			t.Errorf("unexpected error, want '%v' actual '%v'", want, err)
+		}
+		mErr, ok := err.(*ArgumentsMismatchError)
+		if !ok {
+			t.Fatalf("unexpected error type %T", err)
+		}
+		if mErr.MockName() != "Reader" {
+			t.Error("unexpected MockName")
+		}
+		if mErr.MethodName() != "Read" {
+			t.Error("unexpected MethodName")
+		}
+		if len(mErr.Args()) != 1 {
+			t.Error("unexpected Args")
+		}
+	})
+
+	t.Run("Nil matchers", func(t *testing.T) {
+		reader := NewReaderMock()
+		reader.RegisterMatch("Read", nil, func(p []byte) (n int, err error) {
+			return len(p), nil
+		})
+		if n, err := reader.Read([]byte{1, 2}); err != nil || n != 2 {
+			t.Errorf("unexpected n '%v', err '%v'", n, err)
+		}
+	})
+
+	t.Run("Arity mismatch", func(t *testing.T) {
+		var r interface{}
+		func() {
+			defer func() { r = recover() }()
+			New("Reader").RegisterMatch("Read", []Matcher{Any(), Any()},
+				func(p []byte) (n int, err error) { return })
+		}()
+		aErr, ok := r.(*ArityMismatchError)
+		if !ok {
+			t.Fatalf("unexpected panic '%v'", r)
+		}
+		want := "Reader.Read() method registered with 2 matchers for 1 params"
+		if aErr.Error() != want {
+			t.Errorf("unexpected error, want '%v' actual '%v'", want, aErr)
+		}
+
+		method := NewMethod()
+		method.AddMatchedMethodCall(func(p []byte) {}, []Matcher{Any(), Any()})
+		_, err := method.Call([]interface{}{[]byte{1}})
+		mErr, ok := err.(*MismatchError)
+		if !ok || mErr.Argument != NoArgument {
+			t.Fatalf("unexpected error '%v'", err)
+		}
+		if !strings.HasSuffix(mErr.Error(), "expects 2 arguments") {
+			t.Errorf("unexpected error '%v'", mErr)
+		}
+	})
+
+	t.Run("NewWithT", func(t *testing.T) {
+		var (
+			tb   = &testingTB{}
+			mock = NewWithT("Reader", tb)
+			err  = NewUnknownMethodCallError("Reader", "Read")
+		)
+		func() {
+			defer func() {
+				if r := recover(); r != errFatal {
+					t.Errorf("unexpected recover result '%v'", r)
+				}
+			}()
+			mock.Fail(err)
+		}()
+		if len(tb.fatals) != 1 || tb.fatals[0] != err.Error() {
+			t.Errorf("unexpected fatals '%v'", tb.fatals)
+		}
+
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mock.Fail(err)
+		}()
+		wg.Wait()
+		if len(tb.errs) != 1 || tb.errs[0] != err.Error() {
+			t.Errorf("unexpected errs '%v'", tb.errs)
+		}
+
+		location := nextLineLocation()
+		mock.Register("Read", func(p []byte) (n int, err error) { return })
+		tb.cleanup()
+		want := "Reader.Read() calls count: want 1, actual 0\n\tRead() " +
+			"registered at " + location + ": want 1, actual 0"
+		if len(tb.errs) != 2 || tb.errs[1] != want {
+			t.Errorf("unexpected errs '%v'", tb.errs)
+		}
+	})
+
+	t.Run("Fail without T", func(t *testing.T) {
+		var (
+			mock = New("Reader")
+			err  = NewUnknownMethodCallError("Reader", "Read")
+		)
+		defer func() {
+			if r := recover(); r != err {
+				t.Errorf("unexpected recover result '%v'", r)
+			}
+		}()
+		mock.Fail(err)
+	})
+
+	t.Run("Invalid times", func(t *testing.T) {
+		var r interface{}
+		func() {
+			defer func() { r = recover() }()
+			NewReaderMock().RegisterTimes("Read", Between(3, 1),
+				func(p []byte) (n int, err error) { return })
+		}()
+		if err, ok := r.(error); !ok || !errors.Is(err, ErrInvalidTimes) {
+			t.Errorf("unexpected panic '%v'", r)
+		}
+
+		tb := &testingTB{}
+		mock := NewWithT("Reader", tb)
+		func() {
+			defer func() { r = recover() }()
+			mock.RegisterTimes("Read", AtLeast(-1),
+				func(p []byte) (n int, err error) { return })
+		}()
+		want := "Reader.Read() method: invalid number of calls: min -1, max -1"
+		if r != errFatal || len(tb.fatals) != 1 || tb.fatals[0] != want {
+			t.Errorf("unexpected fatals '%v'", tb.fatals)
+		}
+		if arr := mock.CheckCalls(); len(arr) != 0 {
+			t.Errorf("invalid registration was added '%v'", arr)
+		}
+	})
+
+	t.Run("Times", func(t *testing.T) {
+		reader := NewReaderMock()
+		reader.RegisterMatchTimes("Read", AtMost(2), []Matcher{Len(1)},
+			func(p []byte) (n int, err error) {
+				return 1, nil
+			})
+		reader.RegisterTimes("Read", AtLeast(1),
+			func(p []byte) (n int, err error) {
+				return 0, io.EOF
+			})
+		reader.RegisterTimes("Write", Never(),
+			func(p []byte) (n int, err error) {
+				return
+			})
+		if arr := reader.CheckCalls(); len(arr) != 1 {
+			t.Fatalf("unexpected CheckCalls result '%v'", arr)
+		} else {
+			want := "Reader.Read() calls count: want at least 1, actual 0"
+			if !strings.HasPrefix(arr[0].String(), want+"\n") {
+				t.Errorf("unexpected info, want '%v' actual '%v'", want, arr[0])
+			}
+		}
+		for i, want := range []int{1, 1, 0, 0, 0} {
+			n, _ := reader.Read([]byte{1})
+			if n != want {
+				t.Errorf("unexpected n of %v call, want '%v' actual '%v'", i, want, n)
+			}
+		}
+		if arr := reader.CheckCalls(); len(arr) != 0 {
+			t.Errorf("unexpected CheckCalls result '%v'", arr)
+		}
+		_, err := reader.Call("Write", []byte{})
+		if _, ok := err.(*UnexpectedMethodCallError); !ok {
+			t.Errorf("unexpected error '%v'", err)
+		}
+	})
+
+	t.Run("Times violation", func(t *testing.T) {
+		reader := NewReaderMock()
+		location := nextLineLocation()
+		reader.RegisterTimes("Read", Between(2, 3),
+			func(p []byte) (n int, err error) {
+				return
+			})
+		reader.Read(nil)
+		arr := reader.CheckCalls()
+		if len(arr) != 1 {
+			t.Fatalf("unexpected CheckCalls result '%v'", arr)
+		}
+		want := MethodCallsInfo{"Reader", "Read", 2, 1, 3,
+			[]RegistrationInfo{{"Read", location, Between(2, 3), 1}}}
+		if !reflect.DeepEqual(arr[0], want) {
+			t.Errorf("unexpected info, want '%v' actual '%v'", want, arr[0])
+		}
+	})
+
+	t.Run("Wait", func(t *testing.T) {
+		reader := NewReaderMock()
+		reader.RegisterN("Read", 2, func(p []byte) (n int, err error) {
+			time.Sleep(10 * time.Millisecond)
+			return
+		})
+		for i := 0; i < 2; i++ {
+			go reader.Read(nil)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := reader.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if n := len(reader.Calls("Read")); n != 2 {
+			t.Errorf("unexpected calls count, want '%v' actual '%v'", 2, n)
+		}
+	})
+
+	t.Run("WaitMethod", func(t *testing.T) {
+		reader := NewReaderMock()
+		reader.RegisterTimes("Read", AnyTimes(),
+			func(p []byte) (n int, err error) {
+				return
+			})
+		go func() {
+			for i := 0; i < 3; i++ {
+				reader.Read(nil)
+			}
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := reader.WaitMethod(ctx, "Read", 3); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Wait timeout", func(t *testing.T) {
+		reader := NewReaderMock()
+		location := nextLineLocation()
+		reader.RegisterN("Read", 2, func(p []byte) (n int, err error) {
+			return
+		})
+		want := "waiting for Reader method calls: context deadline exceeded " +
+			"[Reader.Read() calls count: want 2, actual 1" +
+			"\n\tRead() registered at " + location + ": want 1, actual 1" +
+			"\n\tRead() registered at " + location + ": want 1, actual 0]"
+		reader.Read(nil)
+		ctx, cancel := context.WithTimeout(context.Background(),
+			10*time.Millisecond)
+		defer cancel()
+		err := reader.Wait(ctx)
+		if err == nil || err.Error() != want {
+			t.Errorf("unexpected error, want '%v' actual '%v'", want, err)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Error("unexpected cause")
+		}
+	})
+
+	t.Run("Wait for FailNow", func(t *testing.T) {
+		var (
+			tb     = &testingTB{}
+			reader = NewReaderMock()
+		)
+		reader.Register("Read", func(p []byte) (n int, err error) {
+			tb.FailNow()
+			return
+		})
+		go reader.Read(nil)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := reader.Wait(ctx); err != nil {
+			t.Fatalf("unexpected error '%v'", err)
+		}
+		history := reader.History()
+		if len(history) != 1 || !history[0].Aborted || history[0].Panic != nil {
+			t.Errorf("unexpected history '%v'", history)
+		}
+		if len(tb.fatals) != 1 {
+			t.Errorf("unexpected fatals '%v'", tb.fatals)
+		}
+	})
+
+	t.Run("Wait for panic", func(t *testing.T) {
+		reader := NewReaderMock()
+		reader.Register("Read", func(p []byte) (n int, err error) {
+			panic("boom")
+		})
+		recovered := make(chan interface{}, 1)
+		go func() {
+			defer func() { recovered <- recover() }()
+			reader.Read(nil)
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := reader.WaitMethod(ctx, "Read", 1); err != nil {
+			t.Fatalf("unexpected error '%v'", err)
+		}
+		if r := <-recovered; r != "boom" {
+			t.Errorf("panic was not propagated '%v'", r)
+		}
+		calls := reader.Calls("Read")
+		if len(calls) != 1 || !calls[0].Aborted || calls[0].Panic != "boom" {
+			t.Errorf("unexpected calls '%v'", calls)
+		}
+	})
+
+	t.Run("WaitMethod timeout", func(t *testing.T) {
+		want := "waiting for Reader method calls: context deadline exceeded " +
+			"[Reader.Read() calls count: want at least 1, actual 0]"
+		reader := NewReaderMock()
+		ctx, cancel := context.WithTimeout(context.Background(),
+			10*time.Millisecond)
+		defer cancel()
+		err := reader.WaitMethod(ctx, "Read", 1)
+		if err == nil || err.Error() != want {
+			t.Errorf("unexpected error, want '%v' actual '%v'", want, err)
+		}
+	})
+
+	t.Run("Wrapping", func(t *testing.T) {
+		reader := ReaderMock{NewWrapping("Reader", bytes.NewReader([]byte{1, 2}))}
+		reader.RegisterRead(func(p []byte) (n int, err error) {
+			return 0, io.ErrUnexpectedEOF
+		})
+		_, err := reader.Read(make([]byte, 1))
+		if err != io.ErrUnexpectedEOF {
+			t.Errorf("unexpected error '%v'", err)
+		}
+		p := make([]byte, 2)
+		n, err := reader.Read(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 2 || !bytes.Equal(p, []byte{1, 2}) {
+			t.Errorf("unexpected delegated call result n = '%v' p = '%v'", n, p)
+		}
+		vals, err := reader.Call("Len")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if vals[0] != 0 {
+			t.Errorf("unexpected Len, want '%v' actual '%v'", 0, vals[0])
+		}
+		_, err = reader.Call("Unknown")
+		if _, ok := err.(*UnknownMethodCallError); !ok {
+			t.Errorf("unexpected error '%v'", err)
+		}
+
+		calls := reader.Calls("Read")
+		if len(calls) != 2 || calls[0].Delegated || !calls[1].Delegated {
+			t.Errorf("unexpected calls '%v'", calls)
+		}
+		if len(reader.History()) != 3 {
+			t.Error("unexpected history")
+		}
+		if arr := reader.CheckCalls(); len(arr) != 0 {
+			t.Errorf("unexpected CheckCalls result '%v'", arr)
+		}
+	})
+
+	t.Run("ForInterface", func(t *testing.T) {
+		readerType := reflect.TypeOf((*io.Reader)(nil)).Elem()
+		register := func(name MethodName, fn Func) (r interface{}) {
+			defer func() { r = recover() }()
+			New("Reader").ForInterface(readerType).Register(name, fn)
+			return
+		}
+		if r := register("Read", func(p []byte) (n int, err error) {
+			return
+		}); r != nil {
+			t.Errorf("unexpected panic '%v'", r)
+		}
+
+		r := register("Raed", func(p []byte) (n int, err error) { return })
+		uErr, ok := r.(*UndefinedMethodError)
+		if !ok {
+			t.Fatalf("unexpected panic '%v'", r)
+		}
+		want := "Reader.Raed() method is not defined by the io.Reader interface"
+		if uErr.Error() != want {
+			t.Errorf("unexpected error, want '%v' actual '%v'", want, uErr)
+		}
+
+		r = register("Read", func(p []byte) error { return nil })
+		sErr, ok := r.(*SignatureMismatchError)
+		if !ok {
+			t.Fatalf("unexpected panic '%v'", r)
+		}
+		want = "Reader.Read() method registered with func([]uint8) error " +
+			"function, want func([]uint8) (int, error)"
+		if sErr.Error() != want {
+			t.Errorf("unexpected error, want '%v' actual '%v'", want, sErr)
+		}
+		if sErr.Want() != readerType.Method(0).Type {
+			t.Error("unexpected Want")
+		}
+	})
+
+	t.Run("ForInterface not interface", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != ErrNotInterface {
+				t.Errorf("unexpected panic '%v'", r)
+			}
+		}()
+		New("Reader").ForInterface(reflect.TypeOf(0))
+	})
+
+	t.Run("History", func(t *testing.T) {
+		reader := NewReaderMock()
+		reader.RegisterN("Read", 2, func(p []byte) (n int, err error) {
+			return len(p), nil
+		})
+		reader.Register("Close", func() error { return nil })
+		reader.Read([]byte{1})
+		reader.Call("Close")
+		reader.Read([]byte{1, 2})
+		reader.Read([]byte{})
+
+		history := reader.History()
+		if len(history) != 3 {
+			t.Fatalf("unexpected history len, want '%v' actual '%v'", 3,
+				len(history))
+		}
+		names := []MethodName{history[0].MethodName, history[1].MethodName,
+			history[2].MethodName}
+		if !reflect.DeepEqual(names, []MethodName{"Read", "Close", "Read"}) {
+			t.Errorf("unexpected method names '%v'", names)
+		}
+		calls := reader.Calls("Read")
+		if len(calls) != 2 {
+			t.Fatalf("unexpected calls len, want '%v' actual '%v'", 2, len(calls))
+		}
+		record := calls[1]
+		if record.Index != 1 {
+			t.Errorf("unexpected Index, want '%v' actual '%v'", 1, record.Index)
+		}
+		if !reflect.DeepEqual(record.Args, []interface{}{[]byte{1, 2}}) {
+			t.Errorf("unexpected Args '%v'", record.Args)
+		}
+		if !reflect.DeepEqual(record.Results, []interface{}{2, nil}) {
+			t.Errorf("unexpected Results '%v'", record.Results)
+		}
+		if record.End.Before(record.Start) {
+			t.Error("unexpected End")
+		}
+		reader.ResetHistory()
+		if len(reader.History()) != 0 || len(reader.CheckCalls()) != 0 {
+			t.Error("unexpected history or calls after reset")
+		}
+	})
+
+	t.Run("Goroutine", func(t *testing.T) {
+		reader := NewReaderMock()
+		reader.RegisterN("Read", 3, func(p []byte) (n int, err error) {
+			return
+		})
+		reader.Read(nil)
+		reader.RecordGoroutines()
+		reader.Read(nil)
+		id := make(chan uint64, 1)
+		go func() {
+			id <- goroutineID()
+			reader.Read(nil)
+		}()
+		spawned := <-id
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := reader.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+		calls := reader.Calls("Read")
+		if calls[0].Goroutine != 0 {
+			t.Errorf("unexpected Goroutine '%v'", calls[0].Goroutine)
+		}
+		if calls[1].Goroutine != goroutineID() {
+			t.Errorf("unexpected Goroutine, want '%v' actual '%v'", goroutineID(),
+				calls[1].Goroutine)
+		}
+		if spawned == goroutineID() || calls[2].Goroutine != spawned {
+			t.Errorf("unexpected Goroutine, want '%v' actual '%v'", spawned,
+				calls[2].Goroutine)
+		}
+	})
+
+	t.Run("Concurrent history", func(t *testing.T) {
+		reader := NewReaderMock()
+		reader.RegisterN("Read", 100, func(p []byte) (n int, err error) {
+			return
+		})
+		wg := sync.WaitGroup{}
+		for i := 0; i < 100; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				reader.Read(nil)
+			}()
+			go func() {
+				defer wg.Done()
+				reader.History()
+			}()
+		}
+		wg.Wait()
+		if len(reader.Calls("Read")) != 100 {
+			t.Error("unexpected calls len")
+		}
+	})
+
+	t.Run("Variadic method", func(t *testing.T) {
+		logger := New("Logger")
+		logger.RegisterN("Log", 2, func(format string, args ...interface{}) int {
+			return len(args)
+		})
+		vals, err := logger.Call("Log", "%v %v", []interface{}{1, 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if vals[0] != 2 {
+			t.Errorf("unexpected result, want '%v' actual '%v'", 2, vals[0])
+		}
+		vals, err = logger.Call("Log", "", []interface{}(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if vals[0] != 0 {
+			t.Errorf("unexpected result, want '%v' actual '%v'", 0, vals[0])
+		}
+		args := logger.Calls("Log")[0].Args
+		if !reflect.DeepEqual(args[1], []interface{}{1, 2}) {
+			t.Errorf("unexpected args '%v'", args)
+		}
+	})
+
 	t.Run("Nil_param_caveat", func(t *testing.T) {
 		writer := NeWriterToMock()
 		writer.RegisterWriteTo(func(w io.Writer) (n int64, err error) {
@@ -238,6 +868,32 @@ func TestMock(t *testing.T) {
 			t.Error(err)
 		}
 	})
+
+	t.Run("reflect.Value param", func(t *testing.T) {
+		var (
+			mock = New("Encoder")
+			want = reflect.ValueOf("value")
+		)
+		mock.Register("Encode", func(val reflect.Value, w io.Writer) bool {
+			return val == want && w == nil
+		})
+		mock.Register("Encode", func(val reflect.Value, w io.Writer) bool {
+			return !val.IsValid() && w == nil
+		})
+		for _, val := range []reflect.Value{want, {}} {
+			vals, err := mock.Call("Encode", val, Wrap[io.Writer](nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !vals[0].(bool) {
+				t.Errorf("unexpected params for '%v'", val)
+			}
+		}
+		args := mock.Calls("Encode")[0].Args
+		if args[0] != want || args[1] != nil {
+			t.Errorf("unexpected args '%v'", args)
+		}
+	})
 }
 
 func CheckMethodCallsInfo(info MethodCallsInfo, expectedCalls,
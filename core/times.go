@@ -0,0 +1,82 @@
+package core
+
+import "fmt"
+
+// Unlimited is the Times.Max value of a registration without an upper bound.
+const Unlimited = -1
+
+// Times describes how many times a registered function is expected to be
+// called.
+type Times struct {
+	Min int
+	Max int // If equal to Unlimited, the number of calls is not limited.
+}
+
+// Exactly creates Times for exactly n calls.
+func Exactly(n int) Times {
+	return Times{n, n}
+}
+
+// AtLeast creates Times for n or more calls.
+func AtLeast(n int) Times {
+	return Times{n, Unlimited}
+}
+
+// AtMost creates Times for up to n calls.
+func AtMost(n int) Times {
+	return Times{0, n}
+}
+
+// Between creates Times for min to max calls inclusive. Registration fails
+// if min is greater than max or any of them is negative, see Validate.
+func Between(min, max int) Times {
+	return Times{min, max}
+}
+
+// AnyTimes creates Times for any number of calls, including zero.
+func AnyTimes() Times {
+	return Times{0, Unlimited}
+}
+
+// Never creates Times for no calls at all.
+func Never() Times {
+	return Times{0, 0}
+}
+
+// Validate returns an error, that wraps ErrInvalidTimes, if the bounds are
+// negative or Min is greater than Max, so no number of calls satisfies them.
+func (times Times) Validate() error {
+	if times.Min < 0 || (times.Max != Unlimited &&
+		(times.Max < 0 || times.Min > times.Max)) {
+		return fmt.Errorf("%w: min %v, max %v", ErrInvalidTimes, times.Min,
+			times.Max)
+	}
+	return nil
+}
+
+// Satisfied checks if n calls are expected.
+func (times Times) Satisfied(n int) bool {
+	return n >= times.Min && (times.Max == Unlimited || n <= times.Max)
+}
+
+// Exhausted checks if no more calls are expected after n calls.
+func (times Times) Exhausted(n int) bool {
+	return times.Max != Unlimited && n >= times.Max
+}
+
+func (times Times) String() string {
+	return formatRange(times.Min, times.Max)
+}
+
+func formatRange(min, max int) string {
+	switch {
+	case max == Unlimited:
+		return fmt.Sprintf("at least %v", min)
+	case min == max:
+		return fmt.Sprint(min)
+	case min == 0:
+		return fmt.Sprintf("at most %v", max)
+	default:
+		return fmt.Sprintf("between %v and %v", min, max)
+	}
+}
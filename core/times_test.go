@@ -0,0 +1,38 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTimesValidate(t *testing.T) {
+	cases := []struct {
+		name  string
+		times Times
+		valid bool
+	}{
+		{"Exactly", Exactly(2), true},
+		{"Never", Never(), true},
+		{"AtLeast", AtLeast(1), true},
+		{"AtMost", AtMost(3), true},
+		{"Between", Between(1, 3), true},
+		{"Between equal", Between(2, 2), true},
+		{"AnyTimes", AnyTimes(), true},
+		{"Between min greater than max", Between(3, 1), false},
+		{"Exactly negative", Exactly(-1), false},
+		{"AtLeast negative", AtLeast(-1), false},
+		{"AtMost negative", AtMost(-2), false},
+		{"Between negative", Between(-1, 2), false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.times.Validate()
+			if c.valid && err != nil {
+				t.Errorf("unexpected error '%v'", err)
+			}
+			if !c.valid && !errors.Is(err, ErrInvalidTimes) {
+				t.Errorf("unexpected error '%v'", err)
+			}
+		})
+	}
+}
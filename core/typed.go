@@ -0,0 +1,167 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// NewTypedMock creates new TypedMock.
+func NewTypedMock(name MockName) *TypedMock {
+	return &TypedMock{name: name}
+}
+
+// NewTypedMockWithT creates new TypedMock, which reports failures to t, like
+// the mock created with NewWithT.
+// Should be called from the test goroutine.
+func NewTypedMockWithT(name MockName, t testing.TB) *TypedMock {
+	mock := &TypedMock{name: name, t: t, tID: goroutineID()}
+	checkCallsAtCleanup(t, mock.CheckCalls)
+	return mock
+}
+
+// TypedMock is a reflection-free alternative to Mock. Its methods are
+// TypedMethods, which hold registered functions of the concrete types, so
+// method calls need neither reflection nor boxing of params and results.
+// In exchange, argument matchers, call history and delegation to a real
+// object are not supported.
+type TypedMock struct {
+	name    MockName
+	methods []typedMethod
+	mu      sync.Mutex
+	t       testing.TB
+	tID     uint64
+}
+
+// Fail handles an error returned by the TypedMethod.Next method, see
+// Mock.Fail.
+func (mock *TypedMock) Fail(err error) {
+	fail(mock.t, mock.tID, err)
+}
+
+// CheckCalls checks method calls. If all registered methods were called the
+// estimated number of times, an empty array is returned.
+func (mock *TypedMock) CheckCalls() []MethodCallsInfo {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	arr := []MethodCallsInfo{}
+	for _, method := range mock.methods {
+		if info, ok := method.CheckCalls(); !ok {
+			arr = append(arr, info)
+		}
+	}
+	return arr
+}
+
+// AddTypedMethod creates a new method of the mock. F is a type of the method
+// functions.
+func AddTypedMethod[F any](mock *TypedMock, name MethodName) *TypedMethod[F] {
+	method := &TypedMethod[F]{mockName: mock.name, name: name, fail: mock.Fail}
+	mock.mu.Lock()
+	mock.methods = append(mock.methods, method)
+	mock.mu.Unlock()
+	return method
+}
+
+type typedMethod interface {
+	CheckCalls() (info MethodCallsInfo, ok bool)
+}
+
+// -----------------------------------------------------------------------------
+// TypedMethod represents a method of the TypedMock, F is a type of the method
+// functions.
+type TypedMethod[F any] struct {
+	mockName   MockName
+	name       MethodName
+	callsCount int
+	calls      []*typedCall[F]
+	mu         sync.Mutex
+	fail       func(err error)
+}
+
+// Register registers a function as one method call.
+func (method *TypedMethod[F]) Register(fn F) {
+	method.RegisterTimes(Exactly(1), fn)
+}
+
+// RegisterN registers a function as several method calls.
+func (method *TypedMethod[F]) RegisterN(n int, fn F) {
+	for i := 0; i < n; i++ {
+		method.RegisterTimes(Exactly(1), fn)
+	}
+}
+
+// RegisterTimes registers a function, which is expected to be called the
+// specified number of times, see Mock.RegisterTimes.
+func (method *TypedMethod[F]) RegisterTimes(times Times, fn F) {
+	if err := times.Validate(); err != nil {
+		method.fail(fmt.Errorf("%v.%v() method: %w", method.mockName, method.name,
+			err))
+		return
+	}
+	location := callerLocation()
+	method.mu.Lock()
+	defer method.mu.Unlock()
+	method.calls = append(method.calls,
+		&typedCall[F]{fn: fn, times: times, location: location})
+}
+
+// Unregister unregisters all method calls.
+func (method *TypedMethod[F]) Unregister() {
+	method.mu.Lock()
+	defer method.mu.Unlock()
+	method.calls = nil
+	method.callsCount = 0
+}
+
+// Next returns the function, registered for the next method call.
+// If no function was registered, UnknownMethodCallError is returned. If all
+// registered method calls have already been made, UnexpectedMethodCallError is
+// returned.
+// Threadsafe.
+func (method *TypedMethod[F]) Next() (fn F, err error) {
+	method.mu.Lock()
+	defer method.mu.Unlock()
+	if len(method.calls) == 0 {
+		err = NewUnknownMethodCallError(method.mockName, method.name)
+		return
+	}
+	for _, call := range method.calls {
+		if !call.times.Exhausted(call.count) {
+			call.count++
+			method.callsCount++
+			return call.fn, nil
+		}
+	}
+	err = &UnexpectedMethodCallError{method.mockName, method.name,
+		method.registrations()}
+	return
+}
+
+// CheckCalls checks method calls, see Method.CheckCalls.
+func (method *TypedMethod[F]) CheckCalls() (info MethodCallsInfo, ok bool) {
+	method.mu.Lock()
+	defer method.mu.Unlock()
+	return checkRegistrations(method.mockName, method.name, method.callsCount,
+		method.registrations())
+}
+
+func (method *TypedMethod[F]) registrations() []RegistrationInfo {
+	infos := make([]RegistrationInfo, len(method.calls))
+	for i, call := range method.calls {
+		infos[i] = RegistrationInfo{
+			MethodName:  method.name,
+			Location:    call.location,
+			Times:       call.times,
+			ActualCalls: call.count,
+		}
+	}
+	return infos
+}
+
+type typedCall[F any] struct {
+	fn       F
+	times    Times
+	count    int
+	location string
+}
@@ -0,0 +1,167 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func NewTypedReaderMock() TypedReaderMock {
+	mock := NewTypedMock("Reader")
+	return TypedReaderMock{
+		TypedMock: mock,
+		read:      AddTypedMethod[func(p []byte) (n int, err error)](mock, "Read"),
+	}
+}
+
+// Reflection-free mock implementation of the io.Reader interface.
+type TypedReaderMock struct {
+	*TypedMock
+	read *TypedMethod[func(p []byte) (n int, err error)]
+}
+
+func (reader TypedReaderMock) RegisterRead(
+	fn func(p []byte) (n int, err error)) TypedReaderMock {
+	Helper()
+	reader.read.Register(fn)
+	return reader
+}
+
+func (reader TypedReaderMock) Read(p []byte) (n int, err error) {
+	fn, err := reader.read.Next()
+	if err != nil {
+		return 0, err
+	}
+	return fn(p)
+}
+
+// -----------------------------------------------------------------------------
+func TestTypedMock(t *testing.T) {
+
+	t.Run("Calls ok", func(t *testing.T) {
+		reader := NewTypedReaderMock()
+		reader.RegisterRead(func(p []byte) (n int, err error) {
+			return 1, nil
+		})
+		reader.read.RegisterTimes(AtLeast(1), func(p []byte) (n int, err error) {
+			return len(p), nil
+		})
+		if n, _ := reader.Read([]byte{1, 2, 3}); n != 1 {
+			t.Errorf("unexpected n, want '%v' actual '%v'", 1, n)
+		}
+		if n, _ := reader.Read([]byte{1, 2, 3}); n != 3 {
+			t.Errorf("unexpected n, want '%v' actual '%v'", 3, n)
+		}
+		if arr := reader.CheckCalls(); len(arr) != 0 {
+			t.Errorf("unexpected CheckCalls result '%v'", arr)
+		}
+	})
+
+	t.Run("Unknown method call", func(t *testing.T) {
+		want := NewUnknownMethodCallError("Reader", "Read")
+		reader := NewTypedReaderMock()
+		_, err := reader.Read(nil)
+		if err.Error() != want.Error() {
+			t.Errorf("unexpected error, want '%v' actual '%v'", want, err)
+		}
+	})
+
+	t.Run("Unexpected call", func(t *testing.T) {
+		reader := NewTypedReaderMock()
+		location := nextLineLocation()
+		reader.RegisterRead(func(p []byte) (n int, err error) {
+			return
+		})
+		want := "unexpected Reader.Read() method call\n\tRead() registered at " +
+			location + ": want 1, actual 1"
+		reader.Read(nil)
+		_, err := reader.Read(nil)
+		if err.Error() != want {
+			t.Errorf("unexpected error, want '%v' actual '%v'", want, err)
+		}
+	})
+
+	t.Run("Unregister", func(t *testing.T) {
+		reader := NewTypedReaderMock()
+		reader.RegisterRead(func(p []byte) (n int, err error) {
+			return
+		})
+		reader.read.Unregister()
+		if _, err := reader.Read(nil); err == nil {
+			t.Error("unexpected nil error")
+		}
+		if arr := reader.CheckCalls(); len(arr) != 0 {
+			t.Errorf("unexpected CheckCalls result '%v'", arr)
+		}
+	})
+
+	t.Run("CheckCalls", func(t *testing.T) {
+		reader := NewTypedReaderMock()
+		reader.read.RegisterN(2, func(p []byte) (n int, err error) {
+			return
+		})
+		reader.Read(nil)
+		arr := reader.CheckCalls()
+		if len(arr) != 1 {
+			t.Fatalf("unexpected CheckCalls result '%v'", arr)
+		}
+		if err := CheckMethodCallsInfo(arr[0], 2, 1); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("NewTypedMockWithT", func(t *testing.T) {
+		tb := &testingTB{}
+		mock := NewTypedMockWithT("Reader", tb)
+		AddTypedMethod[func()](mock, "Close").Register(func() {})
+		tb.cleanup()
+		if len(tb.errs) != 1 {
+			t.Errorf("unexpected errs '%v'", tb.errs)
+		}
+	})
+
+	t.Run("Invalid times", func(t *testing.T) {
+		var r interface{}
+		func() {
+			defer func() { r = recover() }()
+			AddTypedMethod[func()](NewTypedMock("Reader"), "Close").RegisterTimes(
+				Between(2, 1), func() {})
+		}()
+		if err, ok := r.(error); !ok || !errors.Is(err, ErrInvalidTimes) {
+			t.Errorf("unexpected panic '%v'", r)
+		}
+	})
+
+}
+
+func BenchmarkMockCall(b *testing.B) {
+	reader := NewReaderMock()
+	reader.RegisterTimes("Read", AnyTimes(), func(p []byte) (n int, err error) {
+		return len(p), nil
+	})
+	p := make([]byte, 8)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		reader.Read(p)
+		// The history grows with each call, so it's reset outside of the timed
+		// section to measure the call itself.
+		if i%1024 == 1023 {
+			b.StopTimer()
+			reader.ResetHistory()
+			b.StartTimer()
+		}
+	}
+}
+
+func BenchmarkTypedMethodCall(b *testing.B) {
+	reader := NewTypedReaderMock()
+	reader.read.RegisterTimes(AnyTimes(), func(p []byte) (n int, err error) {
+		return len(p), nil
+	})
+	p := make([]byte, 8)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		reader.Read(p)
+	}
+}
@@ -0,0 +1,172 @@
+package amock
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines around changes in a hunk.
+const diffContext = 3
+
+// noNewlineMarker follows the last line without the trailing newline.
+const noNewlineMarker = "\\ No newline at end of file"
+
+// unifiedDiff returns the unified diff of the old and new texts, or an empty
+// string if they are equal.
+func unifiedDiff(oldName, newName, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+	var (
+		a     = splitLines(oldText)
+		b     = splitLines(newText)
+		ops   = diffLines(a, b)
+		sb    strings.Builder
+		start = 0
+	)
+	fmt.Fprintf(&sb, "--- %v\n+++ %v\n", oldName, newName)
+	for start < len(ops) {
+		// Find the next change.
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+		// Extend the hunk while changes are closer than 2*diffContext lines.
+		end, equal := start, 0
+		for i := start; i < len(ops) && equal <= 2*diffContext; i++ {
+			if ops[i].kind == ' ' {
+				equal++
+			} else {
+				end, equal = i+1, 0
+			}
+		}
+		from := max(start-diffContext, 0)
+		to := min(end+diffContext, len(ops))
+		writeHunk(&sb, ops[from:to])
+		start = to
+	}
+	return sb.String()
+}
+
+type diffOp struct {
+	kind  byte // ' ', '-' or '+'
+	line  string
+	aLine int // Line number in the old text, starting from 1.
+	bLine int // Line number in the new text, starting from 1.
+}
+
+func writeHunk(sb *strings.Builder, ops []diffOp) {
+	var aStart, aCount, bStart, bCount int
+	for _, op := range ops {
+		if op.kind != '+' {
+			if aCount == 0 {
+				aStart = op.aLine
+			}
+			aCount++
+		}
+		if op.kind != '-' {
+			if bCount == 0 {
+				bStart = op.bLine
+			}
+			bCount++
+		}
+	}
+	if aCount == 0 {
+		aStart = ops[0].aLine - 1
+	}
+	if bCount == 0 {
+		bStart = ops[0].bLine - 1
+	}
+	fmt.Fprintf(sb, "@@ -%v,%v +%v,%v @@\n", aStart, aCount, bStart, bCount)
+	for _, op := range ops {
+		sb.WriteByte(op.kind)
+		if line, ok := strings.CutSuffix(op.line, "\n"); ok {
+			sb.WriteString(line)
+			sb.WriteString("\n" + noNewlineMarker)
+		} else {
+			sb.WriteString(op.line)
+		}
+		sb.WriteByte('\n')
+	}
+}
+
+// diffLines returns edit operations, that turn a into b. The common prefix
+// and suffix are unchanged, so the longest common subsequence is searched only
+// between them, see diffLCS.
+func diffLines(a, b []string) (ops []diffOp) {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	for i := 0; i < prefix; i++ {
+		ops = append(ops, diffOp{kind: ' ', line: a[i], aLine: i + 1,
+			bLine: i + 1})
+	}
+	ops = append(ops, diffLCS(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix],
+		prefix)...)
+	for i, j := len(a)-suffix, len(b)-suffix; i < len(a); i, j = i+1, j+1 {
+		ops = append(ops, diffOp{kind: ' ', line: a[i], aLine: i + 1,
+			bLine: j + 1})
+	}
+	return
+}
+
+// diffLCS finds the longest common subsequence of the lines and returns edit
+// operations, that turn a into b. The offset is the number of lines before a
+// and b.
+func diffLCS(a, b []string, offset int) (ops []diffOp) {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		aLine, bLine := offset+i+1, offset+j+1
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{kind: ' ', line: a[i], aLine: aLine,
+				bLine: bLine})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{kind: '-', line: a[i], aLine: aLine,
+				bLine: bLine})
+			i++
+		default:
+			ops = append(ops, diffOp{kind: '+', line: b[j], aLine: aLine,
+				bLine: bLine})
+			j++
+		}
+	}
+	return
+}
+
+// splitLines splits the text into lines. The last line without the trailing
+// newline keeps the "\n" suffix, so that it differs from the same line with
+// the newline, and is marked by noNewlineMarker in the diff.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	if !strings.HasSuffix(text, "\n") {
+		lines[len(lines)-1] += "\n"
+	}
+	return lines
+}
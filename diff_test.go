@@ -0,0 +1,66 @@
+package amock
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	testCases := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{name: "Equal", old: "a\nb\n", new: "a\nb\n", want: ""},
+		{
+			name: "Changed line",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			new:  "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			want: "--- old\n+++ new\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name: "Separate hunks",
+			old:  "a\n1\n2\n3\n4\n5\n6\n7\n8\nb\n",
+			new:  "1\n2\n3\n4\n5\n6\n7\n8\n",
+			want: "--- old\n+++ new\n@@ -1,4 +1,3 @@\n-a\n 1\n 2\n 3\n@@ -7,4 +6,3 @@\n 6\n 7\n 8\n-b\n",
+		},
+		{
+			name: "No newline at end of file",
+			old:  "a\nb\n",
+			new:  "a\nb",
+			want: "--- old\n+++ new\n@@ -1,2 +1,2 @@\n a\n-b\n+b\n" +
+				"\\ No newline at end of file\n",
+		},
+		{
+			name: "New file",
+			old:  "",
+			new:  "a\n",
+			want: "--- old\n+++ new\n@@ -0,0 +1,1 @@\n+a\n",
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.name, func(t *testing.T) {
+			if diff := unifiedDiff("old", "new", c.old, c.new); diff != c.want {
+				t.Errorf("unexpected diff, want\n%v\nactual\n%v", c.want, diff)
+			}
+		})
+	}
+}
+
+func TestDiffLinesLargeFile(t *testing.T) {
+	// The LCS table of the whole files would take ~80GB.
+	a := make([]string, 100000)
+	for i := range a {
+		a[i] = strconv.Itoa(i)
+	}
+	b := append([]string{}, a...)
+	b[50000] = "changed"
+	diff := unifiedDiff("old", "new", strings.Join(a, "\n")+"\n",
+		strings.Join(b, "\n")+"\n")
+	want := "--- old\n+++ new\n@@ -49998,7 +49998,7 @@\n 49997\n 49998\n 49999\n" +
+		"-50000\n+changed\n 50001\n 50002\n 50003\n"
+	if diff != want {
+		t.Errorf("unexpected diff, want\n%v\nactual\n%v", want, diff)
+	}
+}
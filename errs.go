@@ -0,0 +1,71 @@
+package amock
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"strings"
+)
+
+// ErrPruneUnsupported happens when AMock.Prune is called on AMock, whose
+// persistor doesn't record the generated files.
+var ErrPruneUnsupported = errors.New("prune is unsupported by the persistor")
+
+// NewOutdatedError creates a new OutdatedError.
+func NewOutdatedError(filename, diff string) *OutdatedError {
+	return &OutdatedError{filename, diff}
+}
+
+// OutdatedError happens when the generated file differs from the file on the
+// disk, see AMock.Check.
+type OutdatedError struct {
+	filename string
+	diff     string
+}
+
+// Filename returns the name of the outdated file.
+func (err *OutdatedError) Filename() string {
+	return err.filename
+}
+
+// Diff returns the unified diff between the file on the disk and the
+// generated one.
+func (err *OutdatedError) Diff() string {
+	return err.diff
+}
+
+func (err *OutdatedError) Error() string {
+	return fmt.Sprintf("%v is out of date", err.filename)
+}
+
+// NewTypeCheckError creates a new TypeCheckError.
+func NewTypeCheckError(filename string, errs []types.Error) *TypeCheckError {
+	return &TypeCheckError{filename, errs}
+}
+
+// TypeCheckError happens when the generated file doesn't type-check, or
+// declares a mock implementation, that doesn't implement the interface, see
+// Conf.TypeCheck.
+type TypeCheckError struct {
+	filename string
+	errs     []types.Error
+}
+
+// Filename returns the name of the generated file.
+func (err *TypeCheckError) Filename() string {
+	return err.filename
+}
+
+// Errors returns type errors, positioned in the generated file.
+func (err *TypeCheckError) Errors() []types.Error {
+	return err.errs
+}
+
+func (err *TypeCheckError) Error() string {
+	strs := make([]string, len(err.errs))
+	for i, e := range err.errs {
+		strs[i] = e.Error()
+	}
+	return fmt.Sprintf("%v doesn't type-check:\n%v", err.filename,
+		strings.Join(strs, "\n"))
+}
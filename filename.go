@@ -0,0 +1,68 @@
+package amock
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// DefFilePattern is the default pattern of the generated file name.
+const DefFilePattern = "{{.Name}}" + FilenameExtenstion
+
+// FileData is the data of the Conf.File pattern. The pattern may also use the
+// snake function, that converts a name to snake case, like
+// "{{.Interface | snake}}_mock.go", and the lower one.
+type FileData struct {
+	Interface string // Name of the interface.
+	Name      string // Name of the mock implementation type.
+}
+
+var fileFuncs = template.FuncMap{
+	"snake": snake,
+	"lower": strings.ToLower,
+}
+
+// makeFilename executes the pattern, if it is empty, DefFilePattern is used.
+// The result must be a file name without directories.
+func makeFilename(pattern string, data FileData) (name string, err error) {
+	if pattern == "" {
+		pattern = DefFilePattern
+	}
+	tmpl, err := template.New("file").Funcs(fileFuncs).Parse(pattern)
+	if err != nil {
+		return
+	}
+	buf := bytes.NewBuffer(make([]byte, 0))
+	if err = tmpl.Execute(buf, data); err != nil {
+		return
+	}
+	name = buf.String()
+	if name == "" || name == "." || name == ".." ||
+		strings.ContainsAny(name, `/\`) {
+		err = fmt.Errorf("%q file pattern produces invalid file name %q", pattern,
+			name)
+	}
+	return
+}
+
+// snake converts the name to snake case, like "HTTPServer" to "http_server".
+func snake(name string) string {
+	var (
+		runes = []rune(name)
+		sb    strings.Builder
+	)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && i+1 < len(runes) &&
+					unicode.IsLower(runes[i+1])) {
+				sb.WriteByte('_')
+			}
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
@@ -0,0 +1,76 @@
+package amock
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMakeFilename(t *testing.T) {
+	data := FileData{Interface: "HTTPServer", Name: "ServerMock"}
+	for pattern, want := range map[string]string{
+		"":                                "ServerMock.gen.go",
+		"{{.Interface | snake}}_mock.go":  "http_server_mock.go",
+		"{{.Name | lower}}.go":            "servermock.go",
+		"mock_{{snake .Name}}_test.go":    "mock_server_mock_test.go",
+		"{{snake \"V1Getter\"}}.go":       "v1_getter.go",
+		"{{snake \"ReadCloser\"}}.go":     "read_closer.go",
+		"{{snake \"getURLPath\"}}.gen.go": "get_url_path.gen.go",
+	} {
+		name, err := makeFilename(pattern, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name != want {
+			t.Errorf("unexpected name for %q, want '%v', actual '%v'", pattern, want,
+				name)
+		}
+	}
+	for _, pattern := range []string{"{{.Unknown}}", "{{", "../{{.Name}}.go",
+		"{{if false}}{{end}}"} {
+		if _, err := makeFilename(pattern, data); err == nil {
+			t.Errorf("expected error for %q", pattern)
+		}
+	}
+}
+
+func TestGenerateNames(t *testing.T) {
+	conf := Conf{
+		Package:     "mock",
+		Name:        "ReaderMock",
+		File:        "{{.Interface | snake}}_mock.go",
+		Constructor: "MakeReaderMock",
+		Path:        t.TempDir(),
+	}
+	aMock, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tp := reflect.TypeOf((*io.ReadCloser)(nil)).Elem()
+	if err = aMock.GenerateAs(tp, conf); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(conf.Path, "read_closer_mock.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, str := range []string{
+		"// MakeReaderMock creates a new ReaderMock.\nfunc MakeReaderMock() ReaderMock {",
+		"// MakeReaderMockWithT creates",
+		"func MakeReaderMockWithT(t testing.TB) ReaderMock {",
+		"// MakeReaderMockWrapping creates",
+		"func MakeReaderMockWrapping(real io.ReadCloser) ReaderMock {",
+		"type ReaderMock struct {",
+	} {
+		if !bytes.Contains(data, []byte(str)) {
+			t.Errorf("no '%v' in '%s'", str, data)
+		}
+	}
+	conf.Constructor = "Make-Mock"
+	if err = aMock.GenerateAs(tp, conf); err == nil {
+		t.Error("expected error")
+	}
+}
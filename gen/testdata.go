@@ -4,9 +4,9 @@ package main
 
 import (
 	"github.com/ymz-ncnk/amock"
+	"github.com/ymz-ncnk/amock/amockgen"
+	"github.com/ymz-ncnk/amock/amockgen/text_template"
 	testdata_amockgen "github.com/ymz-ncnk/amock/testdata/amockgen"
-	"github.com/ymz-ncnk/amockgen"
-	"github.com/ymz-ncnk/amockgen/text_template"
 	persistor_mod "github.com/ymz-ncnk/persistor"
 	"golang.org/x/tools/imports"
 )
@@ -18,7 +18,19 @@ func main() {
 	}
 	descs := []amockgen.MockImplDesc{
 		testdata_amockgen.MxTypeDesc,
+		testdata_amockgen.MxTypedTypeDesc,
 		testdata_amockgen.ReaderTypeDesc,
+		testdata_amockgen.ReaderTypedTypeDesc,
+		testdata_amockgen.CollisionTypeDesc,
+		testdata_amockgen.LoggerTypeDesc,
+		testdata_amockgen.LoggerTypedTypeDesc,
+		testdata_amockgen.StoreTypeDesc,
+		testdata_amockgen.StoreTypedTypeDesc,
+		testdata_amockgen.SourceTypeDesc,
+		testdata_amockgen.RegistryTypeDesc,
+		testdata_amockgen.RegistryTypedTypeDesc,
+		testdata_amockgen.EncoderTypeDesc,
+		testdata_amockgen.StoreItemTypeDesc,
 	}
 
 	for i := 0; i < len(descs); i++ {
@@ -1,9 +1,12 @@
 module github.com/ymz-ncnk/amock
 
-go 1.14
+go 1.22.0
 
 require (
-	github.com/ymz-ncnk/amockgen v0.1.2-0.20230223201221-e80a21e616dc
 	github.com/ymz-ncnk/persistor v0.1.1
-	golang.org/x/tools v0.6.0
+	golang.org/x/mod v0.21.0
+	golang.org/x/tools v0.26.0
+	gopkg.in/yaml.v3 v3.0.1
 )
+
+require golang.org/x/sync v0.8.0 // indirect
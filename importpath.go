@@ -0,0 +1,38 @@
+package amock
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+
+	"golang.org/x/mod/modfile"
+)
+
+// importPath returns the import path of the package in the dir, which may not
+// exist yet, by the go.mod file of the enclosing module. Returns an empty
+// string, if the dir is not inside a module.
+func importPath(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for root := abs; ; {
+		data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+		if err == nil {
+			modPath := modfile.ModulePath(data)
+			if modPath == "" {
+				return ""
+			}
+			rel, err := filepath.Rel(root, abs)
+			if err != nil {
+				return ""
+			}
+			return path.Join(modPath, filepath.ToSlash(rel))
+		}
+		parent := filepath.Dir(root)
+		if parent == root {
+			return ""
+		}
+		root = parent
+	}
+}
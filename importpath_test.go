@@ -0,0 +1,17 @@
+package amock
+
+import "testing"
+
+func TestImportPath(t *testing.T) {
+	for dir, want := range map[string]string{
+		".":                  "github.com/ymz-ncnk/amock",
+		"testdata/mock":      "github.com/ymz-ncnk/amock/testdata/mock",
+		"testdata/none/mock": "github.com/ymz-ncnk/amock/testdata/none/mock",
+		t.TempDir():          "",
+	} {
+		if path := importPath(dir); path != want {
+			t.Errorf("unexpected import path of %v, want '%v' actual '%v'", dir, want,
+				path)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package amock
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	persistor_mod "github.com/ymz-ncnk/persistor"
+)
+
+// ManifestFilename is the name of the manifest file, that lists the generated
+// files of the output directory.
+const ManifestFilename = ".amock.json"
+
+// GeneratedHeader marks files generated by AMock. Prune never removes files
+// without it.
+const GeneratedHeader = "// Code generated by amockgen. DO NOT EDIT."
+
+// Manifest lists the files generated in the output directory.
+type Manifest struct {
+	Files []string `json:"files"`
+}
+
+// ReadManifest reads the manifest of the dir. If there is no manifest returns
+// an empty one.
+func ReadManifest(dir string) (manifest Manifest, err error) {
+	filename := filepath.Join(dir, ManifestFilename)
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+		return
+	}
+	if err = json.Unmarshal(data, &manifest); err != nil {
+		err = fmt.Errorf("%v: %w", filename, err)
+	}
+	return
+}
+
+func (manifest Manifest) marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// NewManifestPersistor creates a new ManifestPersistor.
+func NewManifestPersistor(
+	persistor persistor_mod.Persistor) *ManifestPersistor {
+	return &ManifestPersistor{
+		persistor: persistor,
+		produced:  map[string][]string{},
+	}
+}
+
+// ManifestPersistor saves the files with the persistor and records their names
+// in the manifest of the output directory. It also remembers the files it has
+// saved, so that Prune can remove the ones, that are not produced anymore.
+type ManifestPersistor struct {
+	persistor persistor_mod.Persistor
+	mu        sync.Mutex
+	produced  map[string][]string
+}
+
+// Persist saves the file and adds its name to the manifest.
+func (persistor *ManifestPersistor) Persist(name string, data []byte,
+	path string) (err error) {
+	if err = persistor.persistor.Persist(name, data, path); err != nil {
+		return
+	}
+	persistor.mu.Lock()
+	defer persistor.mu.Unlock()
+	dir := filepath.Clean(path)
+	if !slices.Contains(persistor.produced[dir], name) {
+		persistor.produced[dir] = append(persistor.produced[dir], name)
+	}
+	manifest, err := ReadManifest(dir)
+	if err != nil || slices.Contains(manifest.Files, name) {
+		return
+	}
+	manifest.Files = append(manifest.Files, name)
+	slices.Sort(manifest.Files)
+	return persistor.writeManifest(dir, manifest)
+}
+
+// Prune removes generated files from the manifests of the output directories,
+// that were not saved by this persistor, see PruneDir. Besides the directories,
+// in which the persistor has saved something, all directories with a manifest
+// under the roots are pruned, hidden directories, like ".git", are skipped.
+// Returns names of the removed files.
+func (persistor *ManifestPersistor) Prune(roots ...string) (removed []string,
+	err error) {
+	persistor.mu.Lock()
+	defer persistor.mu.Unlock()
+	var (
+		keep = map[string][]string{}
+		dirs = []string{}
+		errs = []error{}
+	)
+	add := func(dir string, files []string) error {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+		if _, ok := keep[abs]; !ok {
+			dirs = append(dirs, dir)
+		}
+		keep[abs] = append(keep[abs], files...)
+		return nil
+	}
+	for dir, files := range persistor.produced {
+		if err = add(dir, files); err != nil {
+			return
+		}
+	}
+	for _, root := range roots {
+		err = filepath.WalkDir(root, func(path string, d fs.DirEntry,
+			err error) error {
+			switch {
+			case err != nil:
+				if path == root && errors.Is(err, fs.ErrNotExist) {
+					err = nil
+				}
+				return err
+			case d.IsDir() && path != root && strings.HasPrefix(d.Name(), "."):
+				return filepath.SkipDir
+			case !d.IsDir() && d.Name() == ManifestFilename:
+				return add(filepath.Dir(path), nil)
+			}
+			return nil
+		})
+		if err != nil {
+			return
+		}
+	}
+	slices.Sort(dirs)
+	for _, dir := range dirs {
+		abs, _ := filepath.Abs(dir)
+		r, err := persistor.pruneDir(dir, keep[abs])
+		removed = append(removed, r...)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return removed, errors.Join(errs...)
+}
+
+func (persistor *ManifestPersistor) pruneDir(dir string, keep []string) (
+	removed []string, err error) {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return
+	}
+	files := []string{}
+	for _, name := range manifest.Files {
+		if !baseName(name) {
+			// The file may be outside the dir, it's not touched.
+			continue
+		}
+		if slices.Contains(keep, name) {
+			files = append(files, name)
+			continue
+		}
+		filename := filepath.Join(dir, name)
+		var ok bool
+		if ok, err = generated(filename); err != nil {
+			return
+		}
+		if !ok {
+			// The file was replaced by the user, it's not ours anymore.
+			continue
+		}
+		if err = os.Remove(filename); err != nil {
+			return
+		}
+		removed = append(removed, filename)
+	}
+	if len(files) == len(manifest.Files) {
+		return
+	}
+	manifest.Files = files
+	err = persistor.writeManifest(dir, manifest)
+	return
+}
+
+func (persistor *ManifestPersistor) writeManifest(dir string,
+	manifest Manifest) (err error) {
+	data, err := manifest.marshal()
+	if err != nil {
+		return
+	}
+	return persistor.persistor.Persist(ManifestFilename, data, dir)
+}
+
+// PruneDir removes files listed in the manifest of the dir, except the keep
+// ones. Files without the GeneratedHeader are never removed, they are only
+// dropped from the manifest, as well as the missing ones and entries, that are
+// not plain file names, like "../x.gen.go". Returns names of the
+// removed files.
+func PruneDir(dir string, keep ...string) (removed []string, err error) {
+	persistor := NewManifestPersistor(persistor_mod.NewHarDrivePersistor())
+	return persistor.pruneDir(filepath.Clean(dir), keep)
+}
+
+// baseName checks whether the name of a manifest entry is a plain file name,
+// without directories.
+func baseName(name string) bool {
+	return name != "" && name != "." && name != ".." &&
+		name == filepath.Base(name) && !strings.ContainsAny(name, `/\`)
+}
+
+// generated checks whether the file has the GeneratedHeader. A missing file
+// is not considered generated.
+func generated(filename string) (ok bool, err error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+		return
+	}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if string(bytes.TrimSuffix(line, []byte("\r"))) == GeneratedHeader {
+			return true, nil
+		}
+		if bytes.HasPrefix(line, []byte("package ")) {
+			break
+		}
+	}
+	return
+}
@@ -0,0 +1,182 @@
+package amock
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	persistor_mod "github.com/ymz-ncnk/persistor"
+)
+
+func TestManifest(t *testing.T) {
+	var (
+		dir    = t.TempDir()
+		reader = reflect.TypeOf((*io.Reader)(nil)).Elem()
+		writer = reflect.TypeOf((*io.Writer)(nil)).Elem()
+		conf   = Conf{Package: "mock", Path: dir}
+	)
+	aMock, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = aMock.GenerateAs(reader, conf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = os.Stat(filepath.Join(dir, ManifestFilename)); !errors.Is(err,
+		fs.ErrNotExist) {
+		t.Errorf("manifest is written without ManifestPersistor '%v'", err)
+	}
+	if aMock, err = NewWithManifest(); err != nil {
+		t.Fatal(err)
+	}
+	if err = aMock.GenerateAs(reader, conf); err != nil {
+		t.Fatal(err)
+	}
+	if err = aMock.GenerateAs(writer, conf); err != nil {
+		t.Fatal(err)
+	}
+	// Not generated by AMock, although listed in the manifest.
+	user := filepath.Join(dir, "User.gen.go")
+	if err = os.WriteFile(user, []byte("package mock\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest.Files = append(manifest.Files, "User.gen.go", "Missing.gen.go")
+	if err = NewManifestPersistor(persistor_mod.NewHarDrivePersistor()).
+		writeManifest(dir, manifest); err != nil {
+		t.Fatal(err)
+	}
+
+	aMock, err = NewWithManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = aMock.GenerateAs(reader, conf); err != nil {
+		t.Fatal(err)
+	}
+	removed, err := aMock.Prune()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{filepath.Join(dir, "Writer.gen.go")}; !slices.Equal(removed,
+		want) {
+		t.Errorf("unexpected removed, want '%v', actual '%v'", want, removed)
+	}
+	for _, name := range []string{"Reader.gen.go", "User.gen.go"} {
+		if _, err = os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Error(err)
+		}
+	}
+	if manifest, err = ReadManifest(dir); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Reader.gen.go"}; !slices.Equal(manifest.Files, want) {
+		t.Errorf("unexpected manifest, want '%v', actual '%v'", want,
+			manifest.Files)
+	}
+
+	if removed, err = PruneDir(dir); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{filepath.Join(dir, "Reader.gen.go")}; !slices.Equal(removed,
+		want) {
+		t.Errorf("unexpected removed, want '%v', actual '%v'", want, removed)
+	}
+
+	aMock = NewWith(aMock.aMockGen, CheckPersistor{})
+	if _, err = aMock.Prune(); !errors.Is(err, ErrPruneUnsupported) {
+		t.Errorf("unexpected error '%v'", err)
+	}
+}
+
+func TestManifestPruneOutsideDir(t *testing.T) {
+	var (
+		root  = t.TempDir()
+		dir   = filepath.Join(root, "mock")
+		sub   = filepath.Join(dir, "sub")
+		other = filepath.Join(root, "other")
+		data  = []byte(GeneratedHeader + "\n\npackage mock\n")
+	)
+	for _, path := range []string{sub, other} {
+		if err := os.MkdirAll(path, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(path, "X.gen.go"), data,
+			0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	manifest := Manifest{Files: []string{"../other/X.gen.go", "sub/X.gen.go",
+		"..", ""}}
+	if err := NewManifestPersistor(persistor_mod.NewHarDrivePersistor()).
+		writeManifest(dir, manifest); err != nil {
+		t.Fatal(err)
+	}
+	removed, err := PruneDir(dir)
+	if err != nil || len(removed) != 0 {
+		t.Errorf("unexpected removed '%v', err '%v'", removed, err)
+	}
+	for _, path := range []string{sub, other} {
+		if _, err = os.Stat(filepath.Join(path, "X.gen.go")); err != nil {
+			t.Error(err)
+		}
+	}
+	if manifest, err = ReadManifest(dir); err != nil || len(manifest.Files) != 0 {
+		t.Errorf("unexpected manifest '%v', err '%v'", manifest.Files, err)
+	}
+}
+
+func TestManifestPruneRoots(t *testing.T) {
+	var (
+		root   = t.TempDir()
+		dir    = filepath.Join(root, "a", "mock")
+		other  = filepath.Join(root, "b", "mock")
+		hidden = filepath.Join(root, ".hidden", "mock")
+		reader = reflect.TypeOf((*io.Reader)(nil)).Elem()
+		writer = reflect.TypeOf((*io.Writer)(nil)).Elem()
+	)
+	aMock, err := NewWithManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{dir, other, hidden} {
+		if err = os.MkdirAll(path, 0755); err != nil {
+			t.Fatal(err)
+		}
+		err = aMock.GenerateAs(writer, Conf{Package: "mock", Path: path})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The other dir is not generated into anymore.
+	if aMock, err = NewWithManifest(); err != nil {
+		t.Fatal(err)
+	}
+	err = aMock.GenerateAs(reader, Conf{Package: "mock", Path: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	removed, err := aMock.Prune(root, filepath.Join(root, "none"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		filepath.Join(dir, "Writer.gen.go"),
+		filepath.Join(other, "Writer.gen.go"),
+	}
+	if !slices.Equal(removed, want) {
+		t.Errorf("unexpected removed, want '%v', actual '%v'", want, removed)
+	}
+	if _, err = os.Stat(filepath.Join(hidden, "Writer.gen.go")); err != nil {
+		t.Error(err)
+	}
+}
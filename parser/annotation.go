@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"github.com/ymz-ncnk/amock/amockgen"
+	"golang.org/x/tools/go/packages"
+)
+
+// Directive marks interfaces to generate mock implementations for. It should be
+// placed into the doc comment of the interface and may be followed by the
+// key=value arguments, like:
+//
+//	//amock:generate name=StoreMock path=testdata/mock
+const Directive = "//amock:generate"
+
+// Annotation describes an interface annotated with the Directive.
+type Annotation struct {
+	IDesc    amockgen.MockImplDesc
+	Args     map[string]string // Directive arguments, a bare key has "true" value.
+	Dir      string            // Directory of the interface package.
+	Position token.Position    // Position of the directive.
+}
+
+// ParseAnnotated finds interfaces annotated with the Directive in the packages
+// that match the patterns, like "./...", and parses them like ParseSource
+// does.
+// If the annotated type is not an interface returns an error, that wraps
+// ErrNotInterface.
+func ParseAnnotated(patterns ...string) (annotations []Annotation,
+	err error) {
+	pkgs, err := packages.Load(&packages.Config{Mode: LoadMode}, patterns...)
+	if err != nil {
+		return
+	}
+	if len(pkgs) == 0 {
+		err = fmt.Errorf("%w: %q patterns match no packages", ErrPackageNotFound,
+			patterns)
+		return
+	}
+	parser := sourceParser{pkgs: map[string]*packages.Package{}}
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		parser.pkgs[pkg.PkgPath] = pkg
+	})
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			err = pkg.Errors[0]
+			return
+		}
+		for _, file := range pkg.Syntax {
+			for _, spec := range annotatedSpecs(file) {
+				annotation := Annotation{
+					Args:     spec.args,
+					Dir:      filepath.Dir(pkg.Fset.Position(file.Pos()).Filename),
+					Position: pkg.Fset.Position(spec.pos),
+				}
+				annotation.IDesc, err = parser.parse(
+					pkg.TypesInfo.Defs[spec.typeSpec.Name])
+				if err != nil {
+					err = fmt.Errorf("%v: %v type: %w", annotation.Position,
+						spec.typeSpec.Name.Name, err)
+					return
+				}
+				annotations = append(annotations, annotation)
+			}
+		}
+	}
+	return
+}
+
+type annotatedSpec struct {
+	typeSpec *ast.TypeSpec
+	args     map[string]string
+	pos      token.Pos
+}
+
+// annotatedSpecs returns type specs, which doc comments contain the Directive.
+// The doc comment of a declaration is used, if it declares a single type.
+func annotatedSpecs(file *ast.File) (specs []annotatedSpec) {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, s := range genDecl.Specs {
+			typeSpec := s.(*ast.TypeSpec)
+			doc := typeSpec.Doc
+			if doc == nil && len(genDecl.Specs) == 1 {
+				doc = genDecl.Doc
+			}
+			if doc == nil {
+				continue
+			}
+			for _, comment := range doc.List {
+				if args, ok := parseDirective(comment.Text); ok {
+					specs = append(specs, annotatedSpec{typeSpec, args,
+						comment.Pos()})
+				}
+			}
+		}
+	}
+	return
+}
+
+func parseDirective(text string) (args map[string]string, ok bool) {
+	rest, ok := strings.CutPrefix(text, Directive)
+	if !ok || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+		return nil, false
+	}
+	args = map[string]string{}
+	for _, field := range strings.Fields(rest) {
+		key, value, found := strings.Cut(field, "=")
+		if !found {
+			value = "true"
+		}
+		args[key] = value
+	}
+	return args, true
+}
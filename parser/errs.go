@@ -3,3 +3,10 @@ package parser
 import "errors"
 
 var ErrNotInterface = errors.New("not interface")
+
+// ErrTypeNotFound happens when the parsed type is not declared in the package.
+var ErrTypeNotFound = errors.New("type not found")
+
+// ErrPackageNotFound happens when the package pattern doesn't match exactly
+// one package.
+var ErrPackageNotFound = errors.New("package not found")
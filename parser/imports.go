@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/ymz-ncnk/amock/amockgen"
+)
+
+// CorePkgPath is the import path of the package imported by the generated code
+// as amock_core.
+const CorePkgPath = "github.com/ymz-ncnk/amock/core"
+
+// reservedAliases are the import aliases already used by the generated code.
+// An empty path means that the alias can't be used at all.
+var reservedAliases = map[string]string{
+	"reflect":    "reflect",
+	"testing":    "testing",
+	"amock_core": CorePkgPath,
+	"amock_m":    "", // The receiver name.
+}
+
+// varName matches names of the params and return variables of the parsed
+// methods, like p0 or r1, aliases can't be the same.
+var varName = regexp.MustCompile(`^(` + ParamName + `|` + ReturnVarName +
+	`)[0-9]+$`)
+
+// importSet collects packages used by the parsed types and assigns them
+// collision-free aliases.
+type importSet struct {
+	byPath  map[string]amockgen.ImportDesc
+	byAlias map[string]string
+	names   map[string]string // Known package names by path.
+}
+
+func newImportSet() *importSet {
+	return &importSet{
+		byPath:  map[string]amockgen.ImportDesc{},
+		byAlias: map[string]string{},
+		names:   map[string]string{},
+	}
+}
+
+// pkgName returns the known name of the package, or guesses it by the path.
+func (set *importSet) pkgName(path string) string {
+	if name, ok := set.names[path]; ok {
+		return name
+	}
+	return defaultPkgName(path)
+}
+
+// alias returns the alias of the package. The package name is used, if it's
+// free, else a number is appended to it, like "errors2". A name like p0, see
+// varName, gets the "_" suffix first.
+func (set *importSet) alias(path, name string) string {
+	if imp, ok := set.byPath[path]; ok {
+		return imp.Alias
+	}
+	if varName.MatchString(name) {
+		name += "_"
+	}
+	alias := name
+	for i := 2; !set.free(alias, path); i++ {
+		alias = name + strconv.Itoa(i)
+	}
+	set.byPath[path] = amockgen.ImportDesc{Name: name, Alias: alias, Path: path}
+	set.byAlias[alias] = path
+	return alias
+}
+
+func (set *importSet) free(alias, path string) bool {
+	if p, ok := reservedAliases[alias]; ok && p != path {
+		return false
+	}
+	_, ok := set.byAlias[alias]
+	return !ok
+}
+
+// has returns true if the name is used as an alias.
+func (set *importSet) has(name string) bool {
+	_, ok := set.byAlias[name]
+	return ok
+}
+
+// list returns imports sorted by path.
+func (set *importSet) list() []amockgen.ImportDesc {
+	imps := make([]amockgen.ImportDesc, 0, len(set.byPath))
+	for _, imp := range set.byPath {
+		imps = append(imps, imp)
+	}
+	sort.Slice(imps, func(i, j int) bool { return imps[i].Path < imps[j].Path })
+	return imps
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// qualifiedIdent matches package path qualified identifiers in the names of
+// the instantiated generic types, like "github.com/user/model.User" in
+// "Repo[github.com/user/model.User]".
+var qualifiedIdent = regexp.MustCompile(
+	`([A-Za-z0-9_~.\-]+(?:/[A-Za-z0-9_~.\-]+)*)\.([A-Za-z_][A-Za-z0-9_]*)`)
+
+// majorVersion matches the major version suffix of the module path, like v2.
+var majorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+// TypeName returns the valid identifier for the named type. The name of
+// the instantiated generic type gets names of the type arguments instead of
+// the brackets, like "RepoUser" for the "Repo[github.com/user/model.User]".
+func TypeName(tp reflect.Type) string {
+	name, args, found := strings.Cut(tp.Name(), "[")
+	if !found {
+		return name
+	}
+	args = qualifiedIdent.ReplaceAllString(args, "$2")
+	args = strings.NewReplacer("[]", " Slice ", "*", " Ptr ",
+		"interface {}", " Any ").Replace(args)
+	words := strings.FieldsFunc(args, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+	for _, word := range words {
+		r := []rune(word)
+		name += string(unicode.ToUpper(r[0])) + string(r[1:])
+	}
+	return name
+}
+
+// instanceString returns the name of the named type, where packages of the
+// type arguments are qualified by their aliases from the imports.
+func instanceString(tp reflect.Type, imports *importSet) string {
+	return qualifiedIdent.ReplaceAllStringFunc(tp.Name(), func(s string) string {
+		m := qualifiedIdent.FindStringSubmatch(s)
+		return imports.alias(m[1], imports.pkgName(m[1])) + "." + m[2]
+	})
+}
+
+// learnPkgNames remembers package names of the named types used by the type,
+// so that packages of the type arguments, which are known only by the path,
+// get right aliases.
+func learnPkgNames(tp reflect.Type, imports *importSet,
+	visited map[reflect.Type]bool) {
+	if visited[tp] {
+		return
+	}
+	visited[tp] = true
+	if tp.PkgPath() != "" {
+		imports.names[tp.PkgPath()] = pkgName(tp)
+		if !strings.Contains(tp.Name(), "[") {
+			return
+		}
+	}
+	switch tp.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Chan:
+		learnPkgNames(tp.Elem(), imports, visited)
+	case reflect.Map:
+		learnPkgNames(tp.Key(), imports, visited)
+		learnPkgNames(tp.Elem(), imports, visited)
+	case reflect.Func:
+		for i := 0; i < tp.NumIn(); i++ {
+			learnPkgNames(tp.In(i), imports, visited)
+		}
+		for i := 0; i < tp.NumOut(); i++ {
+			learnPkgNames(tp.Out(i), imports, visited)
+		}
+	case reflect.Interface:
+		for i := 0; i < tp.NumMethod(); i++ {
+			learnPkgNames(tp.Method(i).Type, imports, visited)
+		}
+	case reflect.Struct:
+		for i := 0; i < tp.NumField(); i++ {
+			learnPkgNames(tp.Field(i).Type, imports, visited)
+		}
+	}
+}
+
+// defaultPkgName guesses the package name by the path, like "yaml" for the
+// "gopkg.in/yaml.v3" or "mod" for the "example.com/mod/v2".
+func defaultPkgName(path string) string {
+	elems := strings.Split(path, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && majorVersion.MatchString(name) {
+		name = elems[len(elems)-2]
+	}
+	name, _, _ = strings.Cut(name, ".")
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return -1
+	}, name)
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "pkg" + name
+	}
+	return name
+}
@@ -2,10 +2,10 @@ package parser
 
 import (
 	"reflect"
-	"regexp"
 	"strconv"
+	"strings"
 
-	"github.com/ymz-ncnk/amockgen"
+	"github.com/ymz-ncnk/amock/amockgen"
 )
 
 // These constants are used to name params and return variables in generated
@@ -17,59 +17,206 @@ const (
 
 // Parse creates amockgen.MockImplDesc from the interface type. If tp is not an
 // interface returns ErrNotInterface.
+// Packages of the interface and of the methods params and return variables
+// types are collected into the iDesc.Imports.
 func Parse(tp reflect.Type) (iDesc amockgen.MockImplDesc, err error) {
+	imports := newImportSet()
+	if iDesc, err = parse(tp, imports); err != nil {
+		return
+	}
+	iDesc.Imports = imports.list()
+	return
+}
+
+// ParseAll performs like Parse for each of the types, errs[i] is the error of
+// the tps[i]. Aliases of the imports are shared between the types, and
+// Imports of each iDesc contain packages of all of them, so that the iDescs
+// can be generated into one file.
+func ParseAll(tps ...reflect.Type) (iDescs []amockgen.MockImplDesc,
+	errs []error) {
+	imports := newImportSet()
+	iDescs = make([]amockgen.MockImplDesc, len(tps))
+	errs = make([]error, len(tps))
+	for i, tp := range tps {
+		iDescs[i], errs[i] = parse(tp, imports)
+	}
+	list := imports.list()
+	for i := range iDescs {
+		if errs[i] == nil {
+			iDescs[i].Imports = list
+		}
+	}
+	return
+}
+
+func parse(tp reflect.Type, imports *importSet) (
+	iDesc amockgen.MockImplDesc, err error) {
 	if tp.Kind() != reflect.Interface {
 		return amockgen.MockImplDesc{}, ErrNotInterface
 	}
+	visited := map[reflect.Type]bool{}
+	for i := 0; i < tp.NumMethod(); i++ {
+		learnPkgNames(tp.Method(i).Type, imports, visited)
+	}
 	iDesc = amockgen.MockImplDesc{
-		InterfaceType: tp.String(),
-		Name:          tp.Name(),
-		Package:       pkg(tp),
+		InterfaceType: typeString(tp, imports),
+		Name:          TypeName(tp),
+		Package:       pkgName(tp),
 		Methods:       []amockgen.MethoDesc{},
 	}
 	for i := 0; i < tp.NumMethod(); i++ {
-		iDesc.Methods = append(iDesc.Methods, parseMethod(tp.Method(i)))
+		iDesc.Methods = append(iDesc.Methods,
+			parseMethod(tp.Method(i), imports))
 	}
 	return
 }
 
-func parseMethod(method reflect.Method) (mDesc amockgen.MethoDesc) {
+func parseMethod(method reflect.Method, imports *importSet) (
+	mDesc amockgen.MethoDesc) {
 	mDesc = amockgen.MethoDesc{
 		Name:       method.Name,
 		Params:     []amockgen.VarDesc{},
 		ReturnVars: []amockgen.VarDesc{},
 	}
 	for i := 0; i < method.Type.NumIn(); i++ {
-		mDesc.Params = append(mDesc.Params, parseParam(i, method.Type.In(i)))
+		mDesc.Params = append(mDesc.Params,
+			parseParam(i, method.Type.In(i), imports))
+	}
+	if method.Type.IsVariadic() {
+		mDesc.Params[len(mDesc.Params)-1].Variadic = true
 	}
 	for i := 0; i < method.Type.NumOut(); i++ {
 		mDesc.ReturnVars = append(mDesc.ReturnVars,
-			parseReturnValue(i, method.Type.Out(i)))
+			parseReturnValue(i, method.Type.Out(i), imports))
 	}
 	return mDesc
 }
 
-func parseParam(index int, tp reflect.Type) (vDesc amockgen.VarDesc) {
+func parseParam(index int, tp reflect.Type, imports *importSet) (
+	vDesc amockgen.VarDesc) {
 	return amockgen.VarDesc{
 		Name:      ParamName + strconv.Itoa(index),
-		Type:      tp.String(),
+		Type:      typeString(tp, imports),
 		Interface: tp.Kind() == reflect.Interface,
 	}
 }
 
-func parseReturnValue(index int, tp reflect.Type) (vDesc amockgen.VarDesc) {
+func parseReturnValue(index int, tp reflect.Type, imports *importSet) (
+	vDesc amockgen.VarDesc) {
 	return amockgen.VarDesc{
 		Name:      ReturnVarName + strconv.Itoa(index),
-		Type:      tp.String(),
+		Type:      typeString(tp, imports),
 		Interface: tp.Kind() == reflect.Interface,
 	}
 }
 
-func pkg(tp reflect.Type) string {
-	re := regexp.MustCompile(`^(.*)\.`)
-	match := re.FindStringSubmatch(tp.String())
-	if len(match) != 2 {
+// typeString returns the type string, like reflect.Type.String() does, but
+// packages are qualified by their aliases from the imports.
+func typeString(tp reflect.Type, imports *importSet) string {
+	if tp.Name() != "" {
+		if tp.PkgPath() == "" {
+			return tp.Name()
+		}
+		return imports.alias(tp.PkgPath(), pkgName(tp)) + "." +
+			instanceString(tp, imports)
+	}
+	switch tp.Kind() {
+	case reflect.Ptr:
+		return "*" + typeString(tp.Elem(), imports)
+	case reflect.Slice:
+		return "[]" + typeString(tp.Elem(), imports)
+	case reflect.Array:
+		return "[" + strconv.Itoa(tp.Len()) + "]" + typeString(tp.Elem(), imports)
+	case reflect.Map:
+		return "map[" + typeString(tp.Key(), imports) + "]" +
+			typeString(tp.Elem(), imports)
+	case reflect.Chan:
+		return chanString(tp, imports)
+	case reflect.Func:
+		return "func" + signatureString(tp, imports)
+	case reflect.Interface:
+		return interfaceString(tp, imports)
+	case reflect.Struct:
+		return structString(tp, imports)
+	default:
+		return tp.String()
+	}
+}
+
+func chanString(tp reflect.Type, imports *importSet) string {
+	elem := typeString(tp.Elem(), imports)
+	switch tp.ChanDir() {
+	case reflect.RecvDir:
+		return "<-chan " + elem
+	case reflect.SendDir:
+		return "chan<- " + elem
+	}
+	if tp.Elem().Kind() == reflect.Chan && tp.Elem().Name() == "" &&
+		tp.Elem().ChanDir() == reflect.RecvDir {
+		return "chan (" + elem + ")"
+	}
+	return "chan " + elem
+}
+
+func signatureString(tp reflect.Type, imports *importSet) string {
+	params := make([]string, tp.NumIn())
+	for i := 0; i < tp.NumIn(); i++ {
+		if tp.IsVariadic() && i == tp.NumIn()-1 {
+			params[i] = "..." + typeString(tp.In(i).Elem(), imports)
+		} else {
+			params[i] = typeString(tp.In(i), imports)
+		}
+	}
+	results := make([]string, tp.NumOut())
+	for i := 0; i < tp.NumOut(); i++ {
+		results[i] = typeString(tp.Out(i), imports)
+	}
+	str := "(" + strings.Join(params, ", ") + ")"
+	switch len(results) {
+	case 0:
+		return str
+	case 1:
+		return str + " " + results[0]
+	default:
+		return str + " (" + strings.Join(results, ", ") + ")"
+	}
+}
+
+func interfaceString(tp reflect.Type, imports *importSet) string {
+	if tp.NumMethod() == 0 {
+		return "interface {}"
+	}
+	methods := make([]string, tp.NumMethod())
+	for i := 0; i < tp.NumMethod(); i++ {
+		methods[i] = tp.Method(i).Name +
+			signatureString(tp.Method(i).Type, imports)
+	}
+	return "interface { " + strings.Join(methods, "; ") + " }"
+}
+
+func structString(tp reflect.Type, imports *importSet) string {
+	if tp.NumField() == 0 {
+		return "struct {}"
+	}
+	fields := make([]string, tp.NumField())
+	for i := 0; i < tp.NumField(); i++ {
+		field := tp.Field(i)
+		fields[i] = typeString(field.Type, imports)
+		if !field.Anonymous {
+			fields[i] = field.Name + " " + fields[i]
+		}
+		if field.Tag != "" {
+			fields[i] += " " + strconv.Quote(string(field.Tag))
+		}
+	}
+	return "struct { " + strings.Join(fields, "; ") + " }"
+}
+
+// pkgName returns the package name of the named type.
+func pkgName(tp reflect.Type) string {
+	if tp.PkgPath() == "" {
 		return ""
 	}
-	return match[1]
+	name, _, _ := strings.Cut(tp.String(), ".")
+	return name
 }
@@ -1,10 +1,15 @@
 package parser
 
 import (
+	"errors"
+	"math/big"
+	"path/filepath"
 	"reflect"
+	"slices"
 	"testing"
 
 	testdata_amockgen "github.com/ymz-ncnk/amock/testdata/amockgen"
+	v1_mock "github.com/ymz-ncnk/amock/testdata/amockgen/v1/mock"
 )
 
 func TestParser(t *testing.T) {
@@ -18,3 +23,229 @@ func TestParser(t *testing.T) {
 		t.Errorf("unexpected iDesc, want '%v', actual '%v'", want, iDesc)
 	}
 }
+
+func TestParseCollisions(t *testing.T) {
+	want := testdata_amockgen.RegistryTypeDesc
+	iDesc, err := Parse(
+		reflect.TypeOf((*testdata_amockgen.Registry)(nil)).Elem())
+	if err != nil {
+		t.Fatal(err)
+	}
+	iDesc.Name = iDesc.Name + "Mock"
+	if !reflect.DeepEqual(iDesc, want) {
+		t.Errorf("unexpected iDesc, want '%v', actual '%v'", want, iDesc)
+	}
+}
+
+func TestParseReflectValue(t *testing.T) {
+	want := testdata_amockgen.EncoderTypeDesc
+	iDesc, err := Parse(
+		reflect.TypeOf((*testdata_amockgen.Encoder)(nil)).Elem())
+	if err != nil {
+		t.Fatal(err)
+	}
+	iDesc.Name = iDesc.Name + "Mock"
+	if !reflect.DeepEqual(iDesc, want) {
+		t.Errorf("unexpected iDesc, want '%v', actual '%v'", want, iDesc)
+	}
+}
+
+func TestParseInstance(t *testing.T) {
+	want := testdata_amockgen.StoreItemTypeDesc
+	iDesc, err := Parse(reflect.TypeOf(
+		(*testdata_amockgen.Store[string, v1_mock.Item])(nil)).Elem())
+	if err != nil {
+		t.Fatal(err)
+	}
+	iDesc.Name = iDesc.Name + "Mock"
+	if !reflect.DeepEqual(iDesc, want) {
+		t.Errorf("unexpected iDesc, want '%v', actual '%v'", want, iDesc)
+	}
+	for tp, want := range map[reflect.Type]string{
+		reflect.TypeOf((*testdata_amockgen.Store[int, []*big.Int])(nil)).Elem():        "StoreIntSlicePtrInt",
+		reflect.TypeOf((*testdata_amockgen.Store[string, map[string]any])(nil)).Elem(): "StoreStringMapStringAny",
+	} {
+		if name := TypeName(tp); name != want {
+			t.Errorf("unexpected name, want '%v', actual '%v'", want, name)
+		}
+	}
+	for path, want := range map[string]string{
+		"gopkg.in/yaml.v3":    "yaml",
+		"example.com/mod/v2":  "mod",
+		"github.com/x/go-cmp": "gocmp",
+		"io":                  "io",
+	} {
+		if name := defaultPkgName(path); name != want {
+			t.Errorf("unexpected name, want '%v', actual '%v'", want, name)
+		}
+	}
+}
+
+func TestParseImports(t *testing.T) {
+	want := testdata_amockgen.CollisionTypeDesc
+	iDesc, err := Parse(
+		reflect.TypeOf((*testdata_amockgen.Collision)(nil)).Elem())
+	if err != nil {
+		t.Error(err)
+	}
+	iDesc.Name = iDesc.Name + "Mock"
+	if !reflect.DeepEqual(iDesc, want) {
+		t.Errorf("unexpected iDesc, want '%v', actual '%v'", want, iDesc)
+	}
+	iDesc, err = ParseSource(want.Imports[0].Path, "Collision")
+	if err != nil {
+		t.Fatal(err)
+	}
+	iDesc.PackageNames = nil // Checked by TestParseSource.
+	iDesc.Name = iDesc.Name + "Mock"
+	if !reflect.DeepEqual(iDesc, want) {
+		t.Errorf("unexpected iDesc, want '%v', actual '%v'", want, iDesc)
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	want := testdata_amockgen.CollisionTypeDesc
+	iDescs, errs := ParseAll(
+		reflect.TypeOf((*testdata_amockgen.Collision)(nil)).Elem(),
+		reflect.TypeOf(testdata_amockgen.Record{}),
+		reflect.TypeOf((*testdata_amockgen.Mx)(nil)).Elem())
+	if errs[0] != nil || errs[2] != nil {
+		t.Fatal(errs)
+	}
+	if errs[1] != ErrNotInterface {
+		t.Errorf("unexpected error '%v'", errs[1])
+	}
+	if !reflect.DeepEqual(iDescs[2].Imports, iDescs[0].Imports) ||
+		len(iDescs[2].Imports) != len(want.Imports)+2 {
+		t.Errorf("unexpected imports '%v'", iDescs[2].Imports)
+	}
+	iDescs[0].Name = iDescs[0].Name + "Mock"
+	iDescs[0].Imports = want.Imports
+	if !reflect.DeepEqual(iDescs[0], want) {
+		t.Errorf("unexpected iDesc, want '%v', actual '%v'", want, iDescs[0])
+	}
+}
+
+func TestParseAnnotated(t *testing.T) {
+	const pkg = "github.com/ymz-ncnk/amock/testdata/annotated"
+	annotations, err := ParseAnnotated(pkg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(annotations) != 2 {
+		t.Fatalf("unexpected annotations '%v'", annotations)
+	}
+	wantArgs := []map[string]string{
+		{"name": "StorageMock", "path": "testdata/mock"},
+		{"typed": "true", "pkg": "clock_mock"},
+	}
+	for i, name := range []string{"Storage", "Clock"} {
+		a := annotations[i]
+		if a.IDesc.InterfaceType != "annotated."+name {
+			t.Errorf("unexpected InterfaceType '%v'", a.IDesc.InterfaceType)
+		}
+		if !reflect.DeepEqual(a.Args, wantArgs[i]) {
+			t.Errorf("unexpected args, want '%v', actual '%v'", wantArgs[i], a.Args)
+		}
+		if filepath.Base(a.Dir) != "annotated" {
+			t.Errorf("unexpected dir '%v'", a.Dir)
+		}
+		if filepath.Base(a.Position.Filename) != "annotated.go" {
+			t.Errorf("unexpected position '%v'", a.Position)
+		}
+	}
+	_, err = ParseAnnotated(pkg + "/invalid")
+	if !errors.Is(err, ErrNotInterface) {
+		t.Errorf("unexpected error '%v'", err)
+	}
+}
+
+func TestParseVariadic(t *testing.T) {
+	want := testdata_amockgen.LoggerTypeDesc
+	iDesc, err := Parse(reflect.TypeOf((*testdata_amockgen.Logger)(nil)).Elem())
+	if err != nil {
+		t.Error(err)
+	}
+	iDesc.Name = iDesc.Name + "Mock"
+	if !reflect.DeepEqual(iDesc, want) {
+		t.Errorf("unexpected iDesc, want '%v', actual '%v'", want, iDesc)
+	}
+}
+
+func TestParseSource(t *testing.T) {
+	const pkg = "github.com/ymz-ncnk/amock/testdata/amockgen"
+
+	t.Run("Generic interface", func(t *testing.T) {
+		want := testdata_amockgen.StoreTypeDesc
+		iDesc, err := ParseSource(pkg, "Store")
+		if err != nil {
+			t.Fatal(err)
+		}
+		iDesc.PackageNames = nil
+		iDesc.Name = iDesc.Name + "Mock"
+		if !reflect.DeepEqual(iDesc, want) {
+			t.Errorf("unexpected iDesc, want '%v', actual '%v'", want, iDesc)
+		}
+	})
+
+	t.Run("Names, order and doc comments", func(t *testing.T) {
+		want := testdata_amockgen.SourceTypeDesc
+		iDesc, err := ParseSource(pkg, "Source")
+		if err != nil {
+			t.Fatal(err)
+		}
+		iDesc.PackageNames = nil
+		iDesc.Name = iDesc.Name + "Mock"
+		if !reflect.DeepEqual(iDesc, want) {
+			t.Errorf("unexpected iDesc, want '%v', actual '%v'", want, iDesc)
+		}
+	})
+
+	t.Run("Names shadowing types", func(t *testing.T) {
+		iDesc, err := ParseSource(pkg, "Shadower")
+		if err != nil {
+			t.Fatal(err)
+		}
+		mDesc := iDesc.Methods[0]
+		names := []string{mDesc.Params[0].Name, mDesc.Params[1].Name,
+			mDesc.ReturnVars[0].Name}
+		if want := []string{"p0", "p1", "r0"}; !reflect.DeepEqual(names, want) {
+			t.Errorf("unexpected names, want '%v', actual '%v'", want, names)
+		}
+	})
+
+	t.Run("Package names", func(t *testing.T) {
+		iDesc, err := ParseSource(pkg, "Shadower")
+		if err != nil {
+			t.Fatal(err)
+		}
+		names := iDesc.PackageNames
+		for _, name := range []string{"Shadower", "Record", "MxTypeDesc"} {
+			if !slices.Contains(names, name) {
+				t.Errorf("no '%v' in '%v'", name, names)
+			}
+		}
+		// Declared by the generated files.
+		if slices.Contains(names, "StoreMock") {
+			t.Errorf("unexpected names '%v'", names)
+		}
+	})
+
+	t.Run("Not interface", func(t *testing.T) {
+		_, err := ParseSource(pkg, "MxTypeDesc")
+		if err != ErrTypeNotFound {
+			t.Errorf("unexpected error '%v'", err)
+		}
+		_, err = ParseSource("math/big", "Int")
+		if err != ErrNotInterface {
+			t.Errorf("unexpected error '%v'", err)
+		}
+	})
+
+	t.Run("Unknown package", func(t *testing.T) {
+		_, err := ParseSource("github.com/ymz-ncnk/amock/unknown", "Store")
+		if err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+}
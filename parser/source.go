@@ -0,0 +1,375 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ymz-ncnk/amock/amockgen"
+	"golang.org/x/tools/go/packages"
+)
+
+// LoadMode is the packages.LoadMode used to load packages from source.
+const LoadMode = packages.NeedName | packages.NeedTypes | packages.NeedSyntax |
+	packages.NeedTypesInfo | packages.NeedTypesSizes | packages.NeedImports |
+	packages.NeedDeps
+
+// reservedNames can't be used as names of params and return variables in
+// generated methods, because they are already used by the generated code.
+var reservedNames = map[string]bool{
+	"reflect":    true,
+	"testing":    true,
+	"amock_core": true,
+	"amock_m":    true,
+}
+
+// ParseSource creates amockgen.MockImplDesc from the source of the name
+// interface, which is declared in the package, specified by the pattern (an
+// import path or a relative path like "./pkg"). Unlike Parse, it supports
+// generic interfaces, keeps names of params and return variables, spelling of
+// types (byte, rune, any), methods declaration order and their doc comments.
+// If the type is not found returns ErrTypeNotFound, if it's not an interface
+// returns ErrNotInterface.
+func ParseSource(pattern, name string) (iDesc amockgen.MockImplDesc,
+	err error) {
+	pkgs, err := packages.Load(&packages.Config{Mode: LoadMode}, pattern)
+	if err != nil {
+		return
+	}
+	if len(pkgs) != 1 {
+		err = fmt.Errorf("%w: %q pattern matches %v packages",
+			ErrPackageNotFound, pattern, len(pkgs))
+		return
+	}
+	if len(pkgs[0].Errors) > 0 {
+		err = pkgs[0].Errors[0]
+		return
+	}
+	parser := sourceParser{pkgs: map[string]*packages.Package{}}
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		parser.pkgs[pkg.PkgPath] = pkg
+	})
+	return parser.parse(pkgs[0].Types.Scope().Lookup(name))
+}
+
+// sourceParser parses interfaces using syntax trees of the loaded packages.
+type sourceParser struct {
+	pkgs    map[string]*packages.Package
+	imports *importSet
+	scope   *types.Scope // Scope of the interface package.
+}
+
+func (parser sourceParser) parse(obj types.Object) (
+	iDesc amockgen.MockImplDesc, err error) {
+	tn, ok := obj.(*types.TypeName)
+	if !ok {
+		err = ErrTypeNotFound
+		return
+	}
+	named, ok := tn.Type().(*types.Named)
+	if !ok {
+		err = ErrTypeNotFound
+		return
+	}
+	iface, ok := named.Underlying().(*types.Interface)
+	if !ok {
+		err = ErrNotInterface
+		return
+	}
+	parser.imports = newImportSet()
+	parser.scope = tn.Pkg().Scope()
+	iDesc = amockgen.MockImplDesc{
+		InterfaceType: parser.imports.alias(tn.Pkg().Path(), tn.Pkg().Name()) +
+			"." + tn.Name(),
+		Name:    tn.Name(),
+		Package: tn.Pkg().Name(),
+	}
+	if tparams := named.TypeParams(); tparams.Len() > 0 {
+		iDesc.TypeParams = make([]amockgen.VarDesc, tparams.Len())
+		for i := 0; i < tparams.Len(); i++ {
+			iDesc.TypeParams[i] = amockgen.VarDesc{
+				Name: tparams.At(i).Obj().Name(),
+				Type: parser.typeString(tparams.At(i).Constraint()),
+			}
+		}
+	}
+	// Collect all imports first, so that names of params and return variables
+	// don't shadow them.
+	for i := 0; i < iface.NumMethods(); i++ {
+		parser.typeString(iface.Method(i).Type())
+	}
+	iDesc.Methods = parser.parseMethods(tn, iface)
+	iDesc.Imports = parser.imports.list()
+	if pkg, ok := parser.pkgs[tn.Pkg().Path()]; ok {
+		iDesc.PackageNames = packageNames(pkg.Syntax)
+	}
+	return
+}
+
+// packageNames returns the sorted package level names declared by the files,
+// except the generated ones, which may be replaced by the generated mock.
+func packageNames(files []*ast.File) (names []string) {
+	for _, file := range files {
+		if ast.IsGenerated(file) {
+			continue
+		}
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					names = append(names, decl.Name.Name)
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						names = append(names, spec.Name.Name)
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							names = append(names, name.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+	names = slices.DeleteFunc(names, func(name string) bool { return name == "_" })
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// parseMethods returns methods in the declaration order. Methods, whose
+// declarations are not found, follow in the go/types order.
+func (parser sourceParser) parseMethods(tn *types.TypeName,
+	iface *types.Interface) (mDescs []amockgen.MethoDesc) {
+	methods := map[string]*types.Func{}
+	for i := 0; i < iface.NumMethods(); i++ {
+		methods[iface.Method(i).Name()] = iface.Method(i)
+	}
+	mDescs = []amockgen.MethoDesc{}
+	for _, decl := range parser.methodDecls(tn, map[*types.TypeName]bool{}) {
+		if method, ok := methods[decl.name]; ok {
+			mDescs = append(mDescs, parser.parseTypesMethod(method, decl.doc))
+			delete(methods, decl.name)
+		}
+	}
+	for i := 0; i < iface.NumMethods(); i++ {
+		if method, ok := methods[iface.Method(i).Name()]; ok {
+			mDescs = append(mDescs, parser.parseTypesMethod(method, ""))
+		}
+	}
+	return
+}
+
+type methodDecl struct {
+	name string
+	doc  string
+}
+
+// methodDecls returns declarations of the interface methods, including
+// embedded ones, in the source order.
+func (parser sourceParser) methodDecls(tn *types.TypeName,
+	visited map[*types.TypeName]bool) (decls []methodDecl) {
+	if tn.Pkg() == nil || visited[tn] {
+		return
+	}
+	visited[tn] = true
+	pkg, ok := parser.pkgs[tn.Pkg().Path()]
+	if !ok || pkg.TypesInfo == nil {
+		return
+	}
+	spec := findTypeSpec(pkg.Syntax, tn.Pos())
+	if spec == nil {
+		return
+	}
+	itype, ok := spec.Type.(*ast.InterfaceType)
+	if !ok {
+		return
+	}
+	for _, field := range itype.Methods.List {
+		if len(field.Names) > 0 {
+			decls = append(decls, methodDecl{
+				name: field.Names[0].Name,
+				doc:  field.Doc.Text(),
+			})
+			continue
+		}
+		tp := types.Unalias(pkg.TypesInfo.TypeOf(field.Type))
+		if named, ok := tp.(*types.Named); ok {
+			decls = append(decls,
+				parser.methodDecls(named.Origin().Obj(), visited)...)
+		}
+	}
+	return
+}
+
+func findTypeSpec(files []*ast.File, pos token.Pos) (spec *ast.TypeSpec) {
+	for _, file := range files {
+		if pos < file.Pos() || pos > file.End() {
+			continue
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			if s, ok := node.(*ast.TypeSpec); ok && s.Name.Pos() == pos {
+				spec = s
+			}
+			return spec == nil
+		})
+	}
+	return
+}
+
+func (parser sourceParser) parseTypesMethod(method *types.Func, doc string) (
+	mDesc amockgen.MethoDesc) {
+	sig := method.Type().(*types.Signature)
+	mDesc = amockgen.MethoDesc{
+		Name:       method.Name(),
+		Doc:        doc,
+		Params:     []amockgen.VarDesc{},
+		ReturnVars: []amockgen.VarDesc{},
+	}
+	names := parser.varNames(sig)
+	for i := 0; i < sig.Params().Len(); i++ {
+		mDesc.Params = append(mDesc.Params,
+			parser.parseTypesVar(names[sig.Params().At(i)], sig.Params().At(i)))
+	}
+	if sig.Variadic() {
+		mDesc.Params[len(mDesc.Params)-1].Variadic = true
+	}
+	for i := 0; i < sig.Results().Len(); i++ {
+		mDesc.ReturnVars = append(mDesc.ReturnVars,
+			parser.parseTypesVar(names[sig.Results().At(i)], sig.Results().At(i)))
+	}
+	return
+}
+
+// parseTypesVar doesn't mark variables of the type parameter type as
+// interfaces, because they could be instantiated with any type.
+func (parser sourceParser) parseTypesVar(name string,
+	v *types.Var) amockgen.VarDesc {
+	_, typeParam := v.Type().(*types.TypeParam)
+	return amockgen.VarDesc{
+		Name:      name,
+		Type:      parser.typeString(v.Type()),
+		Interface: types.IsInterface(v.Type()) && !typeParam,
+		TypeParam: typeParam,
+	}
+}
+
+// varNames keeps the declared names of params and return variables. Blank,
+// missing, reserved ones, or ones that shadow imports, types of the signature
+// or types of the interface package, which are used unqualified, if the mock
+// is generated into the same package, are replaced with the positional names,
+// like p0 or r1.
+func (parser sourceParser) varNames(sig *types.Signature) (
+	names map[*types.Var]string) {
+	var (
+		vars []*types.Var
+		used = map[string]bool{}
+	)
+	for i := 0; i < sig.Params().Len(); i++ {
+		vars = append(vars, sig.Params().At(i))
+	}
+	for i := 0; i < sig.Results().Len(); i++ {
+		vars = append(vars, sig.Results().At(i))
+	}
+	typeNames := map[string]bool{}
+	collectTypeNames(sig, typeNames)
+	valid := func(name string) bool {
+		if name == "" || name == "_" || reservedNames[name] ||
+			strings.HasPrefix(name, "amock_") || parser.imports.has(name) ||
+			typeNames[name] {
+			return false
+		}
+		if _, ok := parser.scope.Lookup(name).(*types.TypeName); ok {
+			return false
+		}
+		// Interface params are wrapped into the <name>Val variables.
+		for _, v := range vars {
+			if v.Name()+"Val" == name {
+				return false
+			}
+		}
+		return true
+	}
+	names = map[*types.Var]string{}
+	for _, v := range vars {
+		if valid(v.Name()) {
+			names[v] = v.Name()
+			used[v.Name()] = true
+		}
+	}
+	for i := 0; i < sig.Params().Len(); i++ {
+		nameVar(sig.Params().At(i), ParamName+strconv.Itoa(i), names, used)
+	}
+	for i := 0; i < sig.Results().Len(); i++ {
+		nameVar(sig.Results().At(i), ReturnVarName+strconv.Itoa(i), names, used)
+	}
+	return
+}
+
+// collectTypeNames adds names of the named types and type parameters, that
+// are used by the type, to the names.
+func collectTypeNames(tp types.Type, names map[string]bool) {
+	switch t := tp.(type) {
+	case *types.Named:
+		names[t.Obj().Name()] = true
+		for i := 0; i < t.TypeArgs().Len(); i++ {
+			collectTypeNames(t.TypeArgs().At(i), names)
+		}
+	case *types.Alias:
+		names[t.Obj().Name()] = true
+		collectTypeNames(types.Unalias(t), names)
+	case *types.TypeParam:
+		names[t.Obj().Name()] = true
+	case *types.Pointer:
+		collectTypeNames(t.Elem(), names)
+	case *types.Slice:
+		collectTypeNames(t.Elem(), names)
+	case *types.Array:
+		collectTypeNames(t.Elem(), names)
+	case *types.Chan:
+		collectTypeNames(t.Elem(), names)
+	case *types.Map:
+		collectTypeNames(t.Key(), names)
+		collectTypeNames(t.Elem(), names)
+	case *types.Signature:
+		collectTypeNames(t.Params(), names)
+		collectTypeNames(t.Results(), names)
+	case *types.Tuple:
+		for i := 0; i < t.Len(); i++ {
+			collectTypeNames(t.At(i).Type(), names)
+		}
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			collectTypeNames(t.Field(i).Type(), names)
+		}
+	case *types.Interface:
+		for i := 0; i < t.NumMethods(); i++ {
+			collectTypeNames(t.Method(i).Type(), names)
+		}
+	}
+}
+
+func nameVar(v *types.Var, name string, names map[*types.Var]string,
+	used map[string]bool) {
+	if _, ok := names[v]; ok {
+		return
+	}
+	for used[name] {
+		name += "_"
+	}
+	names[v] = name
+	used[name] = true
+}
+
+// typeString returns the type string, where packages are qualified by their
+// aliases from the imports.
+func (parser sourceParser) typeString(tp types.Type) string {
+	return types.TypeString(tp, func(pkg *types.Package) string {
+		return parser.imports.alias(pkg.Path(), pkg.Name())
+	})
+}
@@ -0,0 +1,150 @@
+package amock
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing/fstest"
+	"time"
+
+	persistor_mod "github.com/ymz-ncnk/persistor"
+)
+
+// NewMemPersistor creates a new MemPersistor.
+func NewMemPersistor() *MemPersistor {
+	return &MemPersistor{files: fstest.MapFS{}}
+}
+
+// MemPersistor keeps the files in memory, see FS.
+type MemPersistor struct {
+	mu    sync.Mutex
+	files fstest.MapFS
+}
+
+// Persist saves the data as the "path/name" file. The leading separator of
+// an absolute path is trimmed.
+func (persistor *MemPersistor) Persist(name string, data []byte,
+	path string) (err error) {
+	if name == "" {
+		return persistor_mod.ErrUndefinedName
+	}
+	filename := filepath.Join(path, name)
+	filename = filename[len(filepath.VolumeName(filename)):]
+	filename = strings.TrimPrefix(filepath.ToSlash(filename), "/")
+	if !fs.ValidPath(filename) {
+		return fmt.Errorf("%q is not a valid fs.FS path", filename)
+	}
+	persistor.mu.Lock()
+	defer persistor.mu.Unlock()
+	persistor.files[filename] = &fstest.MapFile{
+		Data:    bytes.Clone(data),
+		Mode:    0644,
+		ModTime: time.Now(),
+	}
+	return
+}
+
+// FS returns a snapshot of the saved files, like "testdata/mock/Reader.gen.go".
+func (persistor *MemPersistor) FS() fs.FS {
+	persistor.mu.Lock()
+	defer persistor.mu.Unlock()
+	files := make(fstest.MapFS, len(persistor.files))
+	for filename, file := range persistor.files {
+		files[filename] = file
+	}
+	return files
+}
+
+// NewWriterPersistor creates a new WriterPersistor.
+func NewWriterPersistor(out io.Writer) *WriterPersistor {
+	return &WriterPersistor{out: out}
+}
+
+// WriterPersistor writes the files to the io.Writer, for example, to the
+// stdout. Each file is preceded by the comment with its name, and files are
+// separated by an empty line.
+type WriterPersistor struct {
+	mu      sync.Mutex
+	out     io.Writer
+	written bool
+}
+
+// Persist writes the "path/name" file.
+func (persistor *WriterPersistor) Persist(name string, data []byte,
+	path string) (err error) {
+	if name == "" {
+		return persistor_mod.ErrUndefinedName
+	}
+	persistor.mu.Lock()
+	defer persistor.mu.Unlock()
+	if persistor.written {
+		if _, err = io.WriteString(persistor.out, "\n"); err != nil {
+			return
+		}
+	}
+	_, err = fmt.Fprintf(persistor.out, "// %v\n", filepath.Join(path, name))
+	if err == nil {
+		_, err = persistor.out.Write(data)
+	}
+	persistor.written = true
+	return
+}
+
+// NewAtomicPersistor creates a new AtomicPersistor.
+func NewAtomicPersistor() AtomicPersistor {
+	return AtomicPersistor{Perm: 0644}
+}
+
+// AtomicPersistor saves the files to the disk, so that they are never left
+// half-written: the data is written to a temporary file, which then replaces
+// the target one. If the file already contains the data, it is not touched,
+// so its modification time is preserved.
+type AtomicPersistor struct {
+	Perm fs.FileMode
+}
+
+// Persist saves the data to the "path/name" file. If a path is empty saves
+// the file to the current directory.
+func (persistor AtomicPersistor) Persist(name string, data []byte,
+	path string) (err error) {
+	if name == "" {
+		return persistor_mod.ErrUndefinedName
+	}
+	if path == "" {
+		path = "."
+	}
+	filename := filepath.Join(path, name)
+	actual, err := os.ReadFile(filename)
+	if err == nil && bytes.Equal(actual, data) {
+		return
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	tmp, err := os.CreateTemp(path, "."+name+".*.tmp")
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+	if _, err = tmp.Write(data); err != nil {
+		return
+	}
+	if err = tmp.Chmod(persistor.Perm); err != nil {
+		return
+	}
+	if err = tmp.Close(); err != nil {
+		return
+	}
+	return os.Rename(tmp.Name(), filename)
+}
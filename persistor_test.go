@@ -0,0 +1,153 @@
+package amock
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ymz-ncnk/amock/amockgen/text_template"
+)
+
+func TestMemPersistor(t *testing.T) {
+	aMockGen, err := text_template.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	persistor := NewMemPersistor()
+	aMock := NewWith(aMockGen, persistor)
+	tp := reflect.TypeOf((*io.Reader)(nil)).Elem()
+	if err = aMock.GenerateAs(tp, DefConf); err != nil {
+		t.Fatal(err)
+	}
+	if err = persistor.Persist("Abs.gen.go", []byte("abs"),
+		filepath.Join(string(filepath.Separator), "tmp")); err != nil {
+		t.Fatal(err)
+	}
+	fsys := persistor.FS()
+	data, err := fs.ReadFile(fsys, "testdata/mock/Reader.gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte("type Reader struct")) {
+		t.Errorf("unexpected data '%s'", data)
+	}
+	if data, err = fs.ReadFile(fsys, "tmp/Abs.gen.go"); err != nil ||
+		string(data) != "abs" {
+		t.Errorf("unexpected data '%s', err '%v'", data, err)
+	}
+	if err = persistor.Persist("Reader.gen.go", nil, "../mock"); err == nil {
+		t.Error("expected error")
+	}
+	if _, err = os.Stat("testdata/mock/Reader.gen.go"); err == nil {
+		t.Error("file is saved to the disk")
+	}
+}
+
+func TestWriterPersistor(t *testing.T) {
+	out := &bytes.Buffer{}
+	persistor := NewWriterPersistor(out)
+	if err := persistor.Persist("A.gen.go", []byte("package a\n"),
+		"mock"); err != nil {
+		t.Fatal(err)
+	}
+	if err := persistor.Persist("B.gen.go", []byte("package b\n"),
+		"mock"); err != nil {
+		t.Fatal(err)
+	}
+	want := "// " + filepath.Join("mock", "A.gen.go") + "\npackage a\n\n" +
+		"// " + filepath.Join("mock", "B.gen.go") + "\npackage b\n"
+	if out.String() != want {
+		t.Errorf("unexpected output, want '%v' actual '%v'", want, out)
+	}
+}
+
+func TestAtomicPersistor(t *testing.T) {
+	var (
+		dir       = t.TempDir()
+		filename  = filepath.Join(dir, "Reader.gen.go")
+		persistor = NewAtomicPersistor()
+		past      = time.Now().Add(-time.Hour).Truncate(time.Second)
+	)
+	modTime := func() time.Time {
+		info, err := os.Stat(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return info.ModTime()
+	}
+	if err := persistor.Persist("Reader.gen.go", []byte("v1"), dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(filename, past, past); err != nil {
+		t.Fatal(err)
+	}
+	if err := persistor.Persist("Reader.gen.go", []byte("v1"), dir); err != nil {
+		t.Fatal(err)
+	}
+	if !modTime().Equal(past) {
+		t.Error("unchanged file was rewritten")
+	}
+	if err := persistor.Persist("Reader.gen.go", []byte("v2"), dir); err != nil {
+		t.Fatal(err)
+	}
+	if modTime().Equal(past) {
+		t.Error("changed file was not rewritten")
+	}
+	if data, err := os.ReadFile(filename); err != nil || string(data) != "v2" {
+		t.Errorf("unexpected data '%s', err '%v'", data, err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ".tmp") {
+			t.Errorf("temporary file %v is left", entry.Name())
+		}
+	}
+	if err := persistor.Persist("Reader.gen.go", nil,
+		filepath.Join(dir, "none")); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestAtomicPersistorKeepsUnchangedFile(t *testing.T) {
+	var (
+		dir       = t.TempDir()
+		filename  = filepath.Join(dir, "Reader.gen.go")
+		persistor = AtomicPersistor{Perm: 0600}
+		past      = time.Now().Add(-time.Hour).Truncate(time.Second)
+	)
+	if err := os.WriteFile(filename, []byte("v1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(filename, past, past); err != nil {
+		t.Fatal(err)
+	}
+	if err := persistor.Persist("Reader.gen.go", []byte("v1"), dir); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.ModTime().Equal(past) {
+		t.Errorf("unexpected modification time '%v'", info.ModTime())
+	}
+	if info.Mode().Perm() != 0644 {
+		t.Errorf("unexpected mode '%v'", info.Mode())
+	}
+	if err = persistor.Persist("New.gen.go", []byte("v1"), dir); err != nil {
+		t.Fatal(err)
+	}
+	if info, err = os.Stat(filepath.Join(dir, "New.gen.go")); err != nil ||
+		info.Mode().Perm() != 0600 {
+		t.Errorf("unexpected mode of the new file '%v', err '%v'", info, err)
+	}
+}
@@ -6,14 +6,35 @@ import (
 	"io"
 	"math/big"
 	"reflect"
+	"testing"
 
 	amock_core "github.com/ymz-ncnk/amock/core"
 )
 
-// New creates a new MxMock.
+// NewMxMock creates a new MxMock.
 func NewMxMock() MxMock {
 	return MxMock{
-		Mock: amock_core.New("MxMock"),
+		Mock: amock_core.New("MxMock").ForInterface(
+			reflect.TypeOf((*Mx)(nil)).Elem()),
+	}
+}
+
+// NewMxMockWithT creates a new MxMock, which reports failures
+// to t and checks method calls at the end of the test.
+func NewMxMockWithT(t testing.TB) MxMock {
+	return MxMock{
+		Mock: amock_core.NewWithT("MxMock", t).ForInterface(
+			reflect.TypeOf((*Mx)(nil)).Elem()),
+	}
+}
+
+// NewMxMockWrapping creates a new MxMock, which delegates calls
+// of the unregistered methods, or calls past the registered ones, to the real
+// object.
+func NewMxMockWrapping(real Mx) MxMock {
+	return MxMock{
+		Mock: amock_core.NewWrapping("MxMock", real).ForInterface(
+			reflect.TypeOf((*Mx)(nil)).Elem()),
 	}
 }
 
@@ -23,328 +44,398 @@ type MxMock struct {
 }
 
 // RegisterM1 registers a function as a single M1() method call.
-func (mock MxMock) RegisterM1(
+func (amock_m MxMock) RegisterM1(
 	fn func(p0 int) (r0 float32)) MxMock {
-	mock.Register("M1", fn)
-	return mock
+	amock_core.Helper()
+	amock_m.Register("M1", fn)
+	return amock_m
 }
 
 // RegisterNM1 registers a function as n M1() method calls.
-func (mock MxMock) RegisterNM1(n int,
+func (amock_m MxMock) RegisterNM1(n int,
+	fn func(p0 int) (r0 float32)) MxMock {
+	amock_core.Helper()
+	amock_m.RegisterN("M1", n, fn)
+	return amock_m
+}
+
+// RegisterTimesM1 registers a function, which is expected to be called the specified number of times.
+func (amock_m MxMock) RegisterTimesM1(times amock_core.Times,
 	fn func(p0 int) (r0 float32)) MxMock {
-	mock.RegisterN("M1", n, fn)
-	return mock
+	amock_core.Helper()
+	amock_m.RegisterTimes("M1", times, fn)
+	return amock_m
 }
 
 // UnregisterM1 unregisters M1() method calls.
-func (mock MxMock) UnregisterM1() MxMock {
-	mock.Unregister("M1")
-	return mock
+func (amock_m MxMock) UnregisterM1() MxMock {
+	amock_m.Unregister("M1")
+	return amock_m
 }
 
 // RegisterM10 registers a function as a single M10() method call.
-func (mock MxMock) RegisterM10(
+func (amock_m MxMock) RegisterM10(
 	fn func()) MxMock {
-	mock.Register("M10", fn)
-	return mock
+	amock_core.Helper()
+	amock_m.Register("M10", fn)
+	return amock_m
 }
 
 // RegisterNM10 registers a function as n M10() method calls.
-func (mock MxMock) RegisterNM10(n int,
+func (amock_m MxMock) RegisterNM10(n int,
 	fn func()) MxMock {
-	mock.RegisterN("M10", n, fn)
-	return mock
+	amock_core.Helper()
+	amock_m.RegisterN("M10", n, fn)
+	return amock_m
+}
+
+// RegisterTimesM10 registers a function, which is expected to be called the specified number of times.
+func (amock_m MxMock) RegisterTimesM10(times amock_core.Times,
+	fn func()) MxMock {
+	amock_core.Helper()
+	amock_m.RegisterTimes("M10", times, fn)
+	return amock_m
 }
 
 // UnregisterM10 unregisters M10() method calls.
-func (mock MxMock) UnregisterM10() MxMock {
-	mock.Unregister("M10")
-	return mock
+func (amock_m MxMock) UnregisterM10() MxMock {
+	amock_m.Unregister("M10")
+	return amock_m
 }
 
 // RegisterM2 registers a function as a single M2() method call.
-func (mock MxMock) RegisterM2(
+func (amock_m MxMock) RegisterM2(
 	fn func(p0 *[3]string, p1 []bool) (r0 []*uint, r1 [10]big.Int)) MxMock {
-	mock.Register("M2", fn)
-	return mock
+	amock_core.Helper()
+	amock_m.Register("M2", fn)
+	return amock_m
 }
 
 // RegisterNM2 registers a function as n M2() method calls.
-func (mock MxMock) RegisterNM2(n int,
+func (amock_m MxMock) RegisterNM2(n int,
+	fn func(p0 *[3]string, p1 []bool) (r0 []*uint, r1 [10]big.Int)) MxMock {
+	amock_core.Helper()
+	amock_m.RegisterN("M2", n, fn)
+	return amock_m
+}
+
+// RegisterTimesM2 registers a function, which is expected to be called the specified number of times.
+func (amock_m MxMock) RegisterTimesM2(times amock_core.Times,
 	fn func(p0 *[3]string, p1 []bool) (r0 []*uint, r1 [10]big.Int)) MxMock {
-	mock.RegisterN("M2", n, fn)
-	return mock
+	amock_core.Helper()
+	amock_m.RegisterTimes("M2", times, fn)
+	return amock_m
 }
 
 // UnregisterM2 unregisters M2() method calls.
-func (mock MxMock) UnregisterM2() MxMock {
-	mock.Unregister("M2")
-	return mock
+func (amock_m MxMock) UnregisterM2() MxMock {
+	amock_m.Unregister("M2")
+	return amock_m
 }
 
 // RegisterM3 registers a function as a single M3() method call.
-func (mock MxMock) RegisterM3(
+func (amock_m MxMock) RegisterM3(
 	fn func(p0 chan error)) MxMock {
-	mock.Register("M3", fn)
-	return mock
+	amock_core.Helper()
+	amock_m.Register("M3", fn)
+	return amock_m
 }
 
 // RegisterNM3 registers a function as n M3() method calls.
-func (mock MxMock) RegisterNM3(n int,
+func (amock_m MxMock) RegisterNM3(n int,
 	fn func(p0 chan error)) MxMock {
-	mock.RegisterN("M3", n, fn)
-	return mock
+	amock_core.Helper()
+	amock_m.RegisterN("M3", n, fn)
+	return amock_m
+}
+
+// RegisterTimesM3 registers a function, which is expected to be called the specified number of times.
+func (amock_m MxMock) RegisterTimesM3(times amock_core.Times,
+	fn func(p0 chan error)) MxMock {
+	amock_core.Helper()
+	amock_m.RegisterTimes("M3", times, fn)
+	return amock_m
 }
 
 // UnregisterM3 unregisters M3() method calls.
-func (mock MxMock) UnregisterM3() MxMock {
-	mock.Unregister("M3")
-	return mock
+func (amock_m MxMock) UnregisterM3() MxMock {
+	amock_m.Unregister("M3")
+	return amock_m
 }
 
 // RegisterM4 registers a function as a single M4() method call.
-func (mock MxMock) RegisterM4(
+func (amock_m MxMock) RegisterM4(
 	fn func(p0 io.Reader)) MxMock {
-	mock.Register("M4", fn)
-	return mock
+	amock_core.Helper()
+	amock_m.Register("M4", fn)
+	return amock_m
 }
 
 // RegisterNM4 registers a function as n M4() method calls.
-func (mock MxMock) RegisterNM4(n int,
+func (amock_m MxMock) RegisterNM4(n int,
+	fn func(p0 io.Reader)) MxMock {
+	amock_core.Helper()
+	amock_m.RegisterN("M4", n, fn)
+	return amock_m
+}
+
+// RegisterTimesM4 registers a function, which is expected to be called the specified number of times.
+func (amock_m MxMock) RegisterTimesM4(times amock_core.Times,
 	fn func(p0 io.Reader)) MxMock {
-	mock.RegisterN("M4", n, fn)
-	return mock
+	amock_core.Helper()
+	amock_m.RegisterTimes("M4", times, fn)
+	return amock_m
 }
 
 // UnregisterM4 unregisters M4() method calls.
-func (mock MxMock) UnregisterM4() MxMock {
-	mock.Unregister("M4")
-	return mock
+func (amock_m MxMock) UnregisterM4() MxMock {
+	amock_m.Unregister("M4")
+	return amock_m
 }
 
 // RegisterM5 registers a function as a single M5() method call.
-func (mock MxMock) RegisterM5(
+func (amock_m MxMock) RegisterM5(
 	fn func(p0 io.Reader, p1 io.Writer) (r0 interface{}, r1 io.ReadCloser)) MxMock {
-	mock.Register("M5", fn)
-	return mock
+	amock_core.Helper()
+	amock_m.Register("M5", fn)
+	return amock_m
 }
 
 // RegisterNM5 registers a function as n M5() method calls.
-func (mock MxMock) RegisterNM5(n int,
+func (amock_m MxMock) RegisterNM5(n int,
 	fn func(p0 io.Reader, p1 io.Writer) (r0 interface{}, r1 io.ReadCloser)) MxMock {
-	mock.RegisterN("M5", n, fn)
-	return mock
+	amock_core.Helper()
+	amock_m.RegisterN("M5", n, fn)
+	return amock_m
+}
+
+// RegisterTimesM5 registers a function, which is expected to be called the specified number of times.
+func (amock_m MxMock) RegisterTimesM5(times amock_core.Times,
+	fn func(p0 io.Reader, p1 io.Writer) (r0 interface{}, r1 io.ReadCloser)) MxMock {
+	amock_core.Helper()
+	amock_m.RegisterTimes("M5", times, fn)
+	return amock_m
 }
 
 // UnregisterM5 unregisters M5() method calls.
-func (mock MxMock) UnregisterM5() MxMock {
-	mock.Unregister("M5")
-	return mock
+func (amock_m MxMock) UnregisterM5() MxMock {
+	amock_m.Unregister("M5")
+	return amock_m
 }
 
 // RegisterM6 registers a function as a single M6() method call.
-func (mock MxMock) RegisterM6(
+func (amock_m MxMock) RegisterM6(
 	fn func(p0 interface{})) MxMock {
-	mock.Register("M6", fn)
-	return mock
+	amock_core.Helper()
+	amock_m.Register("M6", fn)
+	return amock_m
 }
 
 // RegisterNM6 registers a function as n M6() method calls.
-func (mock MxMock) RegisterNM6(n int,
+func (amock_m MxMock) RegisterNM6(n int,
+	fn func(p0 interface{})) MxMock {
+	amock_core.Helper()
+	amock_m.RegisterN("M6", n, fn)
+	return amock_m
+}
+
+// RegisterTimesM6 registers a function, which is expected to be called the specified number of times.
+func (amock_m MxMock) RegisterTimesM6(times amock_core.Times,
 	fn func(p0 interface{})) MxMock {
-	mock.RegisterN("M6", n, fn)
-	return mock
+	amock_core.Helper()
+	amock_m.RegisterTimes("M6", times, fn)
+	return amock_m
 }
 
 // UnregisterM6 unregisters M6() method calls.
-func (mock MxMock) UnregisterM6() MxMock {
-	mock.Unregister("M6")
-	return mock
+func (amock_m MxMock) UnregisterM6() MxMock {
+	amock_m.Unregister("M6")
+	return amock_m
 }
 
 // RegisterM7 registers a function as a single M7() method call.
-func (mock MxMock) RegisterM7(
+func (amock_m MxMock) RegisterM7(
 	fn func(p0 chan int, p1 io.Writer) (r0 map[int]big.Int, r1 error)) MxMock {
-	mock.Register("M7", fn)
-	return mock
+	amock_core.Helper()
+	amock_m.Register("M7", fn)
+	return amock_m
 }
 
 // RegisterNM7 registers a function as n M7() method calls.
-func (mock MxMock) RegisterNM7(n int,
+func (amock_m MxMock) RegisterNM7(n int,
 	fn func(p0 chan int, p1 io.Writer) (r0 map[int]big.Int, r1 error)) MxMock {
-	mock.RegisterN("M7", n, fn)
-	return mock
+	amock_core.Helper()
+	amock_m.RegisterN("M7", n, fn)
+	return amock_m
+}
+
+// RegisterTimesM7 registers a function, which is expected to be called the specified number of times.
+func (amock_m MxMock) RegisterTimesM7(times amock_core.Times,
+	fn func(p0 chan int, p1 io.Writer) (r0 map[int]big.Int, r1 error)) MxMock {
+	amock_core.Helper()
+	amock_m.RegisterTimes("M7", times, fn)
+	return amock_m
 }
 
 // UnregisterM7 unregisters M7() method calls.
-func (mock MxMock) UnregisterM7() MxMock {
-	mock.Unregister("M7")
-	return mock
+func (amock_m MxMock) UnregisterM7() MxMock {
+	amock_m.Unregister("M7")
+	return amock_m
 }
 
 // RegisterM8 registers a function as a single M8() method call.
-func (mock MxMock) RegisterM8(
+func (amock_m MxMock) RegisterM8(
 	fn func(p0 map[string]int, p1 *io.Reader, p2 interface{}) (r0 *io.WriteCloser, r1 error, r2 error)) MxMock {
-	mock.Register("M8", fn)
-	return mock
+	amock_core.Helper()
+	amock_m.Register("M8", fn)
+	return amock_m
 }
 
 // RegisterNM8 registers a function as n M8() method calls.
-func (mock MxMock) RegisterNM8(n int,
+func (amock_m MxMock) RegisterNM8(n int,
+	fn func(p0 map[string]int, p1 *io.Reader, p2 interface{}) (r0 *io.WriteCloser, r1 error, r2 error)) MxMock {
+	amock_core.Helper()
+	amock_m.RegisterN("M8", n, fn)
+	return amock_m
+}
+
+// RegisterTimesM8 registers a function, which is expected to be called the specified number of times.
+func (amock_m MxMock) RegisterTimesM8(times amock_core.Times,
 	fn func(p0 map[string]int, p1 *io.Reader, p2 interface{}) (r0 *io.WriteCloser, r1 error, r2 error)) MxMock {
-	mock.RegisterN("M8", n, fn)
-	return mock
+	amock_core.Helper()
+	amock_m.RegisterTimes("M8", times, fn)
+	return amock_m
 }
 
 // UnregisterM8 unregisters M8() method calls.
-func (mock MxMock) UnregisterM8() MxMock {
-	mock.Unregister("M8")
-	return mock
+func (amock_m MxMock) UnregisterM8() MxMock {
+	amock_m.Unregister("M8")
+	return amock_m
 }
 
 // RegisterM9 registers a function as a single M9() method call.
-func (mock MxMock) RegisterM9(
+func (amock_m MxMock) RegisterM9(
 	fn func(p0 *chan int, p1 io.Reader)) MxMock {
-	mock.Register("M9", fn)
-	return mock
+	amock_core.Helper()
+	amock_m.Register("M9", fn)
+	return amock_m
 }
 
 // RegisterNM9 registers a function as n M9() method calls.
-func (mock MxMock) RegisterNM9(n int,
+func (amock_m MxMock) RegisterNM9(n int,
+	fn func(p0 *chan int, p1 io.Reader)) MxMock {
+	amock_core.Helper()
+	amock_m.RegisterN("M9", n, fn)
+	return amock_m
+}
+
+// RegisterTimesM9 registers a function, which is expected to be called the specified number of times.
+func (amock_m MxMock) RegisterTimesM9(times amock_core.Times,
 	fn func(p0 *chan int, p1 io.Reader)) MxMock {
-	mock.RegisterN("M9", n, fn)
-	return mock
+	amock_core.Helper()
+	amock_m.RegisterTimes("M9", times, fn)
+	return amock_m
 }
 
 // UnregisterM9 unregisters M9() method calls.
-func (mock MxMock) UnregisterM9() MxMock {
-	mock.Unregister("M9")
-	return mock
+func (amock_m MxMock) UnregisterM9() MxMock {
+	amock_m.Unregister("M9")
+	return amock_m
 }
 
-func (mock MxMock) M1(p0 int) (r0 float32) {
-	result, err := mock.Call("M1", p0)
-	if err != nil {
-		panic(err)
+func (amock_m MxMock) M1(p0 int) (r0 float32) {
+	amock_result, amock_err := amock_m.Call("M1", p0)
+	if amock_err != nil {
+		amock_m.Fail(amock_err)
+		return
 	}
-	r0 = result[0].(float32)
+	r0 = amock_result[0].(float32)
 	return
 }
 
-func (mock MxMock) M10() {
-	_, err := mock.Call("M10")
-	if err != nil {
-		panic(err)
+func (amock_m MxMock) M10() {
+	_, amock_err := amock_m.Call("M10")
+	if amock_err != nil {
+		amock_m.Fail(amock_err)
 	}
 }
 
-func (mock MxMock) M2(p0 *[3]string, p1 []bool) (r0 []*uint, r1 [10]big.Int) {
-	result, err := mock.Call("M2", p0, p1)
-	if err != nil {
-		panic(err)
+func (amock_m MxMock) M2(p0 *[3]string, p1 []bool) (r0 []*uint, r1 [10]big.Int) {
+	amock_result, amock_err := amock_m.Call("M2", p0, p1)
+	if amock_err != nil {
+		amock_m.Fail(amock_err)
+		return
 	}
-	r0 = result[0].([]*uint)
-	r1 = result[1].([10]big.Int)
+	r0 = amock_result[0].([]*uint)
+	r1 = amock_result[1].([10]big.Int)
 	return
 }
 
-func (mock MxMock) M3(p0 chan error) {
-	_, err := mock.Call("M3", p0)
-	if err != nil {
-		panic(err)
+func (amock_m MxMock) M3(p0 chan error) {
+	_, amock_err := amock_m.Call("M3", p0)
+	if amock_err != nil {
+		amock_m.Fail(amock_err)
 	}
 }
 
-func (mock MxMock) M4(p0 io.Reader) {
-	var p0Val reflect.Value
-	if p0 == nil {
-		p0Val = reflect.Zero(reflect.TypeOf((*io.Reader)(nil)).Elem())
-	} else {
-		p0Val = reflect.ValueOf(p0)
-	}
-	_, err := mock.Call("M4", p0Val)
-	if err != nil {
-		panic(err)
+func (amock_m MxMock) M4(p0 io.Reader) {
+	p0Val := amock_core.Wrap(p0)
+	_, amock_err := amock_m.Call("M4", p0Val)
+	if amock_err != nil {
+		amock_m.Fail(amock_err)
 	}
 }
 
-func (mock MxMock) M5(p0 io.Reader, p1 io.Writer) (r0 interface{}, r1 io.ReadCloser) {
-	var p0Val reflect.Value
-	if p0 == nil {
-		p0Val = reflect.Zero(reflect.TypeOf((*io.Reader)(nil)).Elem())
-	} else {
-		p0Val = reflect.ValueOf(p0)
-	}
-	var p1Val reflect.Value
-	if p1 == nil {
-		p1Val = reflect.Zero(reflect.TypeOf((*io.Writer)(nil)).Elem())
-	} else {
-		p1Val = reflect.ValueOf(p1)
+func (amock_m MxMock) M5(p0 io.Reader, p1 io.Writer) (r0 interface{}, r1 io.ReadCloser) {
+	p0Val := amock_core.Wrap(p0)
+	p1Val := amock_core.Wrap(p1)
+	amock_result, amock_err := amock_m.Call("M5", p0Val, p1Val)
+	if amock_err != nil {
+		amock_m.Fail(amock_err)
+		return
 	}
-	result, err := mock.Call("M5", p0Val, p1Val)
-	if err != nil {
-		panic(err)
-	}
-	r0, _ = result[0].(interface{})
-	r1, _ = result[1].(io.ReadCloser)
+	r0, _ = amock_result[0].(interface{})
+	r1, _ = amock_result[1].(io.ReadCloser)
 	return
 }
 
-func (mock MxMock) M6(p0 interface{}) {
-	var p0Val reflect.Value
-	if p0 == nil {
-		p0Val = reflect.Zero(reflect.TypeOf((*interface{})(nil)).Elem())
-	} else {
-		p0Val = reflect.ValueOf(p0)
-	}
-	_, err := mock.Call("M6", p0Val)
-	if err != nil {
-		panic(err)
+func (amock_m MxMock) M6(p0 interface{}) {
+	p0Val := amock_core.Wrap(p0)
+	_, amock_err := amock_m.Call("M6", p0Val)
+	if amock_err != nil {
+		amock_m.Fail(amock_err)
 	}
 }
 
-func (mock MxMock) M7(p0 chan int, p1 io.Writer) (r0 map[int]big.Int, r1 error) {
-	var p1Val reflect.Value
-	if p1 == nil {
-		p1Val = reflect.Zero(reflect.TypeOf((*io.Writer)(nil)).Elem())
-	} else {
-		p1Val = reflect.ValueOf(p1)
-	}
-	result, err := mock.Call("M7", p0, p1Val)
-	if err != nil {
-		panic(err)
+func (amock_m MxMock) M7(p0 chan int, p1 io.Writer) (r0 map[int]big.Int, r1 error) {
+	p1Val := amock_core.Wrap(p1)
+	amock_result, amock_err := amock_m.Call("M7", p0, p1Val)
+	if amock_err != nil {
+		amock_m.Fail(amock_err)
+		return
 	}
-	r0 = result[0].(map[int]big.Int)
-	r1, _ = result[1].(error)
+	r0 = amock_result[0].(map[int]big.Int)
+	r1, _ = amock_result[1].(error)
 	return
 }
 
-func (mock MxMock) M8(p0 map[string]int, p1 *io.Reader, p2 interface{}) (r0 *io.WriteCloser, r1 error, r2 error) {
-	var p2Val reflect.Value
-	if p2 == nil {
-		p2Val = reflect.Zero(reflect.TypeOf((*interface{})(nil)).Elem())
-	} else {
-		p2Val = reflect.ValueOf(p2)
+func (amock_m MxMock) M8(p0 map[string]int, p1 *io.Reader, p2 interface{}) (r0 *io.WriteCloser, r1 error, r2 error) {
+	p2Val := amock_core.Wrap(p2)
+	amock_result, amock_err := amock_m.Call("M8", p0, p1, p2Val)
+	if amock_err != nil {
+		amock_m.Fail(amock_err)
+		return
 	}
-	result, err := mock.Call("M8", p0, p1, p2Val)
-	if err != nil {
-		panic(err)
-	}
-	r0 = result[0].(*io.WriteCloser)
-	r1, _ = result[1].(error)
-	r2, _ = result[2].(error)
+	r0 = amock_result[0].(*io.WriteCloser)
+	r1, _ = amock_result[1].(error)
+	r2, _ = amock_result[2].(error)
 	return
 }
 
-func (mock MxMock) M9(p0 *chan int, p1 io.Reader) {
-	var p1Val reflect.Value
-	if p1 == nil {
-		p1Val = reflect.Zero(reflect.TypeOf((*io.Reader)(nil)).Elem())
-	} else {
-		p1Val = reflect.ValueOf(p1)
-	}
-	_, err := mock.Call("M9", p0, p1Val)
-	if err != nil {
-		panic(err)
+func (amock_m MxMock) M9(p0 *chan int, p1 io.Reader) {
+	p1Val := amock_core.Wrap(p1)
+	_, amock_err := amock_m.Call("M9", p0, p1Val)
+	if amock_err != nil {
+		amock_m.Fail(amock_err)
 	}
 }
@@ -2,12 +2,38 @@
 
 package amockgen
 
-import amock_core "github.com/ymz-ncnk/amock/core"
+import (
+	"io"
+	"reflect"
+	"testing"
 
-// New creates a new ReaderMock.
+	amock_core "github.com/ymz-ncnk/amock/core"
+)
+
+// NewReaderMock creates a new ReaderMock.
 func NewReaderMock() ReaderMock {
 	return ReaderMock{
-		Mock: amock_core.New("ReaderMock"),
+		Mock: amock_core.New("ReaderMock").ForInterface(
+			reflect.TypeOf((*io.Reader)(nil)).Elem()),
+	}
+}
+
+// NewReaderMockWithT creates a new ReaderMock, which reports failures
+// to t and checks method calls at the end of the test.
+func NewReaderMockWithT(t testing.TB) ReaderMock {
+	return ReaderMock{
+		Mock: amock_core.NewWithT("ReaderMock", t).ForInterface(
+			reflect.TypeOf((*io.Reader)(nil)).Elem()),
+	}
+}
+
+// NewReaderMockWrapping creates a new ReaderMock, which delegates calls
+// of the unregistered methods, or calls past the registered ones, to the real
+// object.
+func NewReaderMockWrapping(real io.Reader) ReaderMock {
+	return ReaderMock{
+		Mock: amock_core.NewWrapping("ReaderMock", real).ForInterface(
+			reflect.TypeOf((*io.Reader)(nil)).Elem()),
 	}
 }
 
@@ -17,31 +43,42 @@ type ReaderMock struct {
 }
 
 // RegisterRead registers a function as a single Read() method call.
-func (mock ReaderMock) RegisterRead(
+func (amock_m ReaderMock) RegisterRead(
 	fn func(p0 []uint8) (r0 int, r1 error)) ReaderMock {
-	mock.Register("Read", fn)
-	return mock
+	amock_core.Helper()
+	amock_m.Register("Read", fn)
+	return amock_m
 }
 
 // RegisterNRead registers a function as n Read() method calls.
-func (mock ReaderMock) RegisterNRead(n int,
+func (amock_m ReaderMock) RegisterNRead(n int,
+	fn func(p0 []uint8) (r0 int, r1 error)) ReaderMock {
+	amock_core.Helper()
+	amock_m.RegisterN("Read", n, fn)
+	return amock_m
+}
+
+// RegisterTimesRead registers a function, which is expected to be called the specified number of times.
+func (amock_m ReaderMock) RegisterTimesRead(times amock_core.Times,
 	fn func(p0 []uint8) (r0 int, r1 error)) ReaderMock {
-	mock.RegisterN("Read", n, fn)
-	return mock
+	amock_core.Helper()
+	amock_m.RegisterTimes("Read", times, fn)
+	return amock_m
 }
 
 // UnregisterRead unregisters Read() method calls.
-func (mock ReaderMock) UnregisterRead() ReaderMock {
-	mock.Unregister("Read")
-	return mock
+func (amock_m ReaderMock) UnregisterRead() ReaderMock {
+	amock_m.Unregister("Read")
+	return amock_m
 }
 
-func (mock ReaderMock) Read(p0 []uint8) (r0 int, r1 error) {
-	result, err := mock.Call("Read", p0)
-	if err != nil {
-		panic(err)
+func (amock_m ReaderMock) Read(p0 []uint8) (r0 int, r1 error) {
+	amock_result, amock_err := amock_m.Call("Read", p0)
+	if amock_err != nil {
+		amock_m.Fail(amock_err)
+		return
 	}
-	r0 = result[0].(int)
-	r1, _ = result[1].(error)
+	r0 = amock_result[0].(int)
+	r1, _ = amock_result[1].(error)
 	return
 }
@@ -3,10 +3,15 @@ package amockgen
 import (
 	"io"
 	"math/big"
+	"reflect"
 
-	"github.com/ymz-ncnk/amockgen"
+	"github.com/ymz-ncnk/amock/amockgen"
+	v1_mock "github.com/ymz-ncnk/amock/testdata/amockgen/v1/mock"
+	v2_mock "github.com/ymz-ncnk/amock/testdata/amockgen/v2/mock"
 )
 
+const pkgPath = "github.com/ymz-ncnk/amock/testdata/amockgen"
+
 type Mx interface {
 	M1(p0 int) (r0 float32)
 	M2(p0 *[3]string, p1 []bool) (r0 []*uint, r1 [10]big.Int)
@@ -24,10 +29,68 @@ type Mx interface {
 	M10()
 }
 
+type Store[K comparable, V any] interface {
+	Get(p0 K) (r0 V, r1 bool)
+	Put(p0 K, p1 V) (r0 error)
+	Keys() (r0 []K)
+}
+
+// Collision uses types of the packages with the same name.
+type Collision interface {
+	Get() (r0 Record)
+	Put(p0 v1_mock.Item, p1 *v2_mock.Item) (r0 map[v1_mock.Item]chan<- v2_mock.Item)
+}
+
+type Record struct{}
+
+// Shadower has params and return variables named after types, that are used
+// unqualified by a mock in this package.
+type Shadower interface {
+	Get(Record string, Store int) (Source Record)
+}
+
+type Logger interface {
+	Log(p0 string, p1 ...interface{}) (r0 int)
+}
+
+// Registry has methods named like the methods of the core mock and the
+// generated helper methods.
+type Registry interface {
+	Register(p0 string) (r0 error)
+	Call(p0 string, p1 ...interface{}) (r0 []interface{}, r1 error)
+	CheckCalls() (r0 int)
+	Unregister(p0 string)
+	Read(p0 []byte) (r0 int, r1 error)
+	RegisterRead(p0 int)
+	Mock()
+}
+
+// Encoder takes and returns reflect.Value.
+type Encoder interface {
+	Encode(p0 reflect.Value, p1 interface{}) (r0 reflect.Value, r1 error)
+}
+
+type Source interface {
+	// Write writes len(p) bytes from p.
+	Write(p []byte) (n int, err error)
+	io.Closer
+	// ReadRune reads a single rune.
+	//
+	// Returns io.EOF at the end.
+	ReadRune() (r rune, size int, err error)
+	Do(v any, amock_m string, vVal int, _ interface{}) error
+	Printf(format string, args ...any)
+}
+
 var MxTypeDesc = amockgen.MockImplDesc{
 	InterfaceType: "amockgen.Mx",
 	Package:       "amockgen",
 	Name:          "MxMock",
+	Imports: []amockgen.ImportDesc{
+		{Name: "amockgen", Alias: "amockgen", Path: pkgPath},
+		{Name: "io", Alias: "io", Path: "io"},
+		{Name: "big", Alias: "big", Path: "math/big"},
+	},
 	Methods: []amockgen.MethoDesc{
 		{
 			Name: "M1",
@@ -126,6 +189,9 @@ var ReaderTypeDesc = amockgen.MockImplDesc{
 	InterfaceType: "io.Reader",
 	Package:       "amockgen",
 	Name:          "ReaderMock",
+	Imports: []amockgen.ImportDesc{
+		{Name: "io", Alias: "io", Path: "io"},
+	},
 	Methods: []amockgen.MethoDesc{
 		{
 			Name: "Read",
@@ -149,3 +215,317 @@ var ReaderTypeDesc = amockgen.MockImplDesc{
 		},
 	},
 }
+
+// io.Reader, reflection-free
+var ReaderTypedTypeDesc = func() amockgen.MockImplDesc {
+	d := ReaderTypeDesc
+	d.Name = "ReaderTypedMock"
+	d.Typed = true
+	return d
+}()
+
+// Mx, reflection-free
+var MxTypedTypeDesc = func() amockgen.MockImplDesc {
+	d := MxTypeDesc
+	d.Name = "MxTypedMock"
+	d.Typed = true
+	return d
+}()
+
+// Collision
+var CollisionTypeDesc = amockgen.MockImplDesc{
+	InterfaceType: "amockgen.Collision",
+	Package:       "amockgen",
+	Name:          "CollisionMock",
+	Imports: []amockgen.ImportDesc{
+		{Name: "amockgen", Alias: "amockgen", Path: pkgPath},
+		{Name: "mock", Alias: "mock", Path: pkgPath + "/v1/mock"},
+		{Name: "mock", Alias: "mock2", Path: pkgPath + "/v2/mock"},
+	},
+	Methods: []amockgen.MethoDesc{
+		{
+			Name:   "Get",
+			Params: []amockgen.VarDesc{},
+			ReturnVars: []amockgen.VarDesc{
+				{Name: "r0", Type: "amockgen.Record"},
+			},
+		},
+		{
+			Name: "Put",
+			Params: []amockgen.VarDesc{
+				{Name: "p0", Type: "mock.Item"},
+				{Name: "p1", Type: "*mock2.Item"},
+			},
+			ReturnVars: []amockgen.VarDesc{
+				{Name: "r0", Type: "map[mock.Item]chan<- mock2.Item"},
+			},
+		},
+	},
+}
+
+// Logger, variadic
+var LoggerTypeDesc = amockgen.MockImplDesc{
+	InterfaceType: "amockgen.Logger",
+	Package:       "amockgen",
+	Name:          "LoggerMock",
+	Imports: []amockgen.ImportDesc{
+		{Name: "amockgen", Alias: "amockgen", Path: pkgPath},
+	},
+	Methods: []amockgen.MethoDesc{
+		{
+			Name: "Log",
+			Params: []amockgen.VarDesc{
+				{Name: "p0", Type: "string"},
+				{Name: "p1", Type: "[]interface {}", Variadic: true},
+			},
+			ReturnVars: []amockgen.VarDesc{
+				{Name: "r0", Type: "int"},
+			},
+		},
+	},
+}
+
+// Logger, variadic, reflection-free
+var LoggerTypedTypeDesc = func() amockgen.MockImplDesc {
+	d := LoggerTypeDesc
+	d.Name = "LoggerTypedMock"
+	d.Typed = true
+	return d
+}()
+
+// Registry, names collide with the core mock and helper methods
+var RegistryTypeDesc = amockgen.MockImplDesc{
+	InterfaceType: "amockgen.Registry",
+	Package:       "amockgen",
+	Name:          "RegistryMock",
+	Imports: []amockgen.ImportDesc{
+		{Name: "amockgen", Alias: "amockgen", Path: pkgPath},
+	},
+	Methods: []amockgen.MethoDesc{
+		{
+			Name: "Call",
+			Params: []amockgen.VarDesc{
+				{Name: "p0", Type: "string"},
+				{Name: "p1", Type: "[]interface {}", Variadic: true},
+			},
+			ReturnVars: []amockgen.VarDesc{
+				{Name: "r0", Type: "[]interface {}"},
+				{Name: "r1", Type: "error", Interface: true},
+			},
+		},
+		{
+			Name:       "CheckCalls",
+			Params:     []amockgen.VarDesc{},
+			ReturnVars: []amockgen.VarDesc{{Name: "r0", Type: "int"}},
+		},
+		{
+			Name:       "Mock",
+			Params:     []amockgen.VarDesc{},
+			ReturnVars: []amockgen.VarDesc{},
+		},
+		{
+			Name:   "Read",
+			Params: []amockgen.VarDesc{{Name: "p0", Type: "[]uint8"}},
+			ReturnVars: []amockgen.VarDesc{
+				{Name: "r0", Type: "int"},
+				{Name: "r1", Type: "error", Interface: true},
+			},
+		},
+		{
+			Name:   "Register",
+			Params: []amockgen.VarDesc{{Name: "p0", Type: "string"}},
+			ReturnVars: []amockgen.VarDesc{
+				{Name: "r0", Type: "error", Interface: true},
+			},
+		},
+		{
+			Name:       "RegisterRead",
+			Params:     []amockgen.VarDesc{{Name: "p0", Type: "int"}},
+			ReturnVars: []amockgen.VarDesc{},
+		},
+		{
+			Name:       "Unregister",
+			Params:     []amockgen.VarDesc{{Name: "p0", Type: "string"}},
+			ReturnVars: []amockgen.VarDesc{},
+		},
+	},
+}
+
+// Registry, reflection-free
+var RegistryTypedTypeDesc = func() amockgen.MockImplDesc {
+	d := RegistryTypeDesc
+	d.Name = "RegistryTypedMock"
+	d.Typed = true
+	return d
+}()
+
+// Store, instantiated with v1_mock.Item
+var StoreItemTypeDesc = amockgen.MockImplDesc{
+	InterfaceType: "amockgen.Store[string,mock.Item]",
+	Package:       "amockgen",
+	Name:          "StoreStringItemMock",
+	Imports: []amockgen.ImportDesc{
+		{Name: "amockgen", Alias: "amockgen", Path: pkgPath},
+		{Name: "mock", Alias: "mock", Path: pkgPath + "/v1/mock"},
+	},
+	Methods: []amockgen.MethoDesc{
+		{
+			Name:   "Get",
+			Params: []amockgen.VarDesc{{Name: "p0", Type: "string"}},
+			ReturnVars: []amockgen.VarDesc{
+				{Name: "r0", Type: "mock.Item"},
+				{Name: "r1", Type: "bool"},
+			},
+		},
+		{
+			Name:       "Keys",
+			Params:     []amockgen.VarDesc{},
+			ReturnVars: []amockgen.VarDesc{{Name: "r0", Type: "[]string"}},
+		},
+		{
+			Name: "Put",
+			Params: []amockgen.VarDesc{
+				{Name: "p0", Type: "string"},
+				{Name: "p1", Type: "mock.Item"},
+			},
+			ReturnVars: []amockgen.VarDesc{
+				{Name: "r0", Type: "error", Interface: true},
+			},
+		},
+	},
+}
+
+// Encoder, reflect.Value params
+var EncoderTypeDesc = amockgen.MockImplDesc{
+	InterfaceType: "amockgen.Encoder",
+	Package:       "amockgen",
+	Name:          "EncoderMock",
+	Imports: []amockgen.ImportDesc{
+		{Name: "amockgen", Alias: "amockgen", Path: pkgPath},
+		{Name: "reflect", Alias: "reflect", Path: "reflect"},
+	},
+	Methods: []amockgen.MethoDesc{
+		{
+			Name: "Encode",
+			Params: []amockgen.VarDesc{
+				{Name: "p0", Type: "reflect.Value"},
+				{Name: "p1", Type: "interface {}", Interface: true},
+			},
+			ReturnVars: []amockgen.VarDesc{
+				{Name: "r0", Type: "reflect.Value"},
+				{Name: "r1", Type: "error", Interface: true},
+			},
+		},
+	},
+}
+
+// Store, generic
+var StoreTypeDesc = amockgen.MockImplDesc{
+	InterfaceType: "amockgen.Store",
+	Package:       "amockgen",
+	Name:          "StoreMock",
+	Imports: []amockgen.ImportDesc{
+		{Name: "amockgen", Alias: "amockgen", Path: pkgPath},
+	},
+	TypeParams: []amockgen.VarDesc{
+		{Name: "K", Type: "comparable"},
+		{Name: "V", Type: "any"},
+	},
+	Methods: []amockgen.MethoDesc{
+		{
+			Name: "Get",
+			Params: []amockgen.VarDesc{
+				{Name: "p0", Type: "K", TypeParam: true},
+			},
+			ReturnVars: []amockgen.VarDesc{
+				{Name: "r0", Type: "V", TypeParam: true},
+				{Name: "r1", Type: "bool"},
+			},
+		},
+		{
+			Name: "Put",
+			Params: []amockgen.VarDesc{
+				{Name: "p0", Type: "K", TypeParam: true},
+				{Name: "p1", Type: "V", TypeParam: true},
+			},
+			ReturnVars: []amockgen.VarDesc{
+				{Name: "r0", Type: "error", Interface: true},
+			},
+		},
+		{
+			Name:   "Keys",
+			Params: []amockgen.VarDesc{},
+			ReturnVars: []amockgen.VarDesc{
+				{Name: "r0", Type: "[]K"},
+			},
+		},
+	},
+}
+
+// Store, generic, reflection-free
+var StoreTypedTypeDesc = func() amockgen.MockImplDesc {
+	d := StoreTypeDesc
+	d.Name = "StoreTypedMock"
+	d.Typed = true
+	return d
+}()
+
+// Source, parsed from the source code
+var SourceTypeDesc = amockgen.MockImplDesc{
+	InterfaceType: "amockgen.Source",
+	Package:       "amockgen",
+	Name:          "SourceMock",
+	Imports: []amockgen.ImportDesc{
+		{Name: "amockgen", Alias: "amockgen", Path: pkgPath},
+	},
+	Methods: []amockgen.MethoDesc{
+		{
+			Name: "Write",
+			Doc:  "Write writes len(p) bytes from p.\n",
+			Params: []amockgen.VarDesc{
+				{Name: "p", Type: "[]byte"},
+			},
+			ReturnVars: []amockgen.VarDesc{
+				{Name: "n", Type: "int"},
+				{Name: "err", Type: "error", Interface: true},
+			},
+		},
+		{
+			Name:   "Close",
+			Params: []amockgen.VarDesc{},
+			ReturnVars: []amockgen.VarDesc{
+				{Name: "r0", Type: "error", Interface: true},
+			},
+		},
+		{
+			Name:   "ReadRune",
+			Doc:    "ReadRune reads a single rune.\n\nReturns io.EOF at the end.\n",
+			Params: []amockgen.VarDesc{},
+			ReturnVars: []amockgen.VarDesc{
+				{Name: "r", Type: "rune"},
+				{Name: "size", Type: "int"},
+				{Name: "err", Type: "error", Interface: true},
+			},
+		},
+		{
+			Name: "Do",
+			Params: []amockgen.VarDesc{
+				{Name: "v", Type: "any", Interface: true},
+				{Name: "p1", Type: "string"},
+				{Name: "p2", Type: "int"},
+				{Name: "p3", Type: "interface{}", Interface: true},
+			},
+			ReturnVars: []amockgen.VarDesc{
+				{Name: "r0", Type: "error", Interface: true},
+			},
+		},
+		{
+			Name: "Printf",
+			Params: []amockgen.VarDesc{
+				{Name: "format", Type: "string"},
+				{Name: "args", Type: "[]any", Variadic: true},
+			},
+			ReturnVars: []amockgen.VarDesc{},
+		},
+	},
+}
@@ -0,0 +1,9 @@
+// Package mock shares its name with another testdata package.
+package mock
+
+type Item struct{}
+
+// Source is mocked into the other package with the same name.
+type Source interface {
+	Item() Item
+}
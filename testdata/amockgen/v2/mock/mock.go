@@ -0,0 +1,4 @@
+// Package mock shares its name with another testdata package.
+package mock
+
+type Item struct{}
@@ -0,0 +1,22 @@
+// Package annotated contains interfaces annotated with the amock:generate
+// directive.
+package annotated
+
+// Storage stores values.
+//
+//amock:generate name=StorageMock path=testdata/mock
+type Storage interface {
+	Load(key string) (value []byte, err error)
+}
+
+type (
+	//amock:generate typed pkg=clock_mock
+	Clock interface {
+		Now() int64
+	}
+
+	// NotAnnotated is skipped.
+	NotAnnotated interface {
+		M()
+	}
+)
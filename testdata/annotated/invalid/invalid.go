@@ -0,0 +1,5 @@
+// Package invalid contains the amock:generate directive on a struct.
+package invalid
+
+//amock:generate
+type Struct struct{}
@@ -1,8 +1,8 @@
 package mock
 
 import (
+	"github.com/ymz-ncnk/amock/amockgen"
 	amock_core "github.com/ymz-ncnk/amock/core"
-	"github.com/ymz-ncnk/amockgen"
 )
 
 func NewAMockGen() AMockGen {
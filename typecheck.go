@@ -0,0 +1,170 @@
+package amock
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	goparser "go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ymz-ncnk/amock/amockgen"
+	"github.com/ymz-ncnk/amock/parser"
+	"golang.org/x/tools/go/packages"
+)
+
+// implementsSuffix is added to the name of the generated file to get the name
+// of the file, that checks whether mock implementations implement their
+// interfaces.
+const implementsSuffix = ".implements"
+
+// typeCheck type-checks the generated file, which contains mock
+// implementations of the iDescs, together with the other files of the target
+// package in the dir, and checks that the mock implementations implement their
+// interfaces. If there are type errors in the generated file, returns
+// TypeCheckError.
+func typeCheck(dir, filename string, data []byte,
+	iDescs ...amockgen.MockImplDesc) (err error) {
+	var (
+		fset = token.NewFileSet()
+		path = filepath.Join(dir, filename)
+		own  = map[string]bool{}
+	)
+	files, err := parseTargetFiles(fset, dir, filename)
+	if err != nil {
+		return
+	}
+	file, err := goparser.ParseFile(fset, path, data, 0)
+	if err != nil {
+		return
+	}
+	files = append(files, file)
+	own[path] = true
+	for i, iDesc := range iDescs {
+		name := fmt.Sprintf("%v%v%v", path, implementsSuffix, i)
+		if file, err = goparser.ParseFile(fset, name, implementsSource(iDesc),
+			0); err != nil {
+			return
+		}
+		files = append(files, file)
+		own[name] = true
+	}
+	pkgs, err := loadImports(files)
+	if err != nil {
+		return
+	}
+	errs := []types.Error{}
+	conf := types.Config{
+		Importer: importerFunc(func(path string) (*types.Package, error) {
+			if pkg, ok := pkgs[path]; ok {
+				return pkg, nil
+			}
+			return nil, fmt.Errorf("%q package is not loaded", path)
+		}),
+		Error: func(err error) {
+			// Errors of the other files of the target package are not caused by
+			// the generated file.
+			if typesErr, ok := err.(types.Error); ok &&
+				own[typesErr.Fset.Position(typesErr.Pos).Filename] {
+				errs = append(errs, typesErr)
+			}
+		},
+	}
+	conf.Check(iDescs[0].Package, fset, files, nil)
+	if len(errs) > 0 {
+		return NewTypeCheckError(path, errs)
+	}
+	return nil
+}
+
+// implementsSource returns the source, that assigns the mock implementation
+// to the interface. The interface package is imported, unless it's the target
+// package itself, see amockgen.LocalImport.
+func implementsSource(iDesc amockgen.MockImplDesc) []byte {
+	var (
+		interfaceType = amockgen.MakeInterfaceType(iDesc)
+		typeParams    = amockgen.MakeTypeParams(amockgen.Localize(iDesc))
+		local, _      = amockgen.LocalImport(iDesc)
+		src           strings.Builder
+	)
+	fmt.Fprintf(&src, "package %v\n\n", iDesc.Package)
+	for _, imp := range iDesc.Imports {
+		re := regexp.MustCompile(`\b` + regexp.QuoteMeta(imp.Alias) + `\.`)
+		if imp != local && (re.MatchString(interfaceType) ||
+			re.MatchString(typeParams)) {
+			fmt.Fprintf(&src, "import %v %v\n", imp.Alias, strconv.Quote(imp.Path))
+		}
+	}
+	fmt.Fprintf(&src, "\nfunc _%v() {\n\tvar _ %v = %v%v{}\n}\n", typeParams,
+		interfaceType, iDesc.Name, amockgen.MakeTypeArgs(iDesc))
+	return []byte(src.String())
+}
+
+// parseTargetFiles parses the package files in the dir, except the previous
+// version of the generated file. The dir may not exist yet.
+func parseTargetFiles(fset *token.FileSet, dir, filename string) (
+	files []*ast.File, err error) {
+	if _, err = os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	pkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		var noGoErr *build.NoGoError
+		if errors.As(err, &noGoErr) {
+			err = nil
+		}
+		return
+	}
+	for _, name := range append(pkg.GoFiles, pkg.CgoFiles...) {
+		if name == filename {
+			continue
+		}
+		file, err := goparser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return
+}
+
+// loadImports loads types of the packages imported by the files.
+func loadImports(files []*ast.File) (pkgs map[string]*types.Package,
+	err error) {
+	paths := []string{}
+	for _, file := range files {
+		for _, imp := range file.Imports {
+			path, _ := strconv.Unquote(imp.Path.Value)
+			paths = append(paths, path)
+		}
+	}
+	pkgs = map[string]*types.Package{}
+	if len(paths) == 0 {
+		return
+	}
+	loaded, err := packages.Load(&packages.Config{Mode: parser.LoadMode},
+		paths...)
+	if err != nil {
+		return
+	}
+	for _, pkg := range loaded {
+		if len(pkg.Errors) > 0 {
+			return nil, pkg.Errors[0]
+		}
+		pkgs[pkg.PkgPath] = pkg.Types
+	}
+	return
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
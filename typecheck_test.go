@@ -0,0 +1,170 @@
+package amock
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ymz-ncnk/amock/amockgen"
+	"github.com/ymz-ncnk/amock/amockgen/text_template"
+	"github.com/ymz-ncnk/amock/core"
+	"github.com/ymz-ncnk/amock/testdata/mock"
+)
+
+func TestTypeCheck(t *testing.T) {
+	var (
+		tp      = reflect.TypeOf((*io.Reader)(nil)).Elem()
+		persist = func(t *testing.T, wantName string) mock.Persistor {
+			return mock.NewPersistor().RegisterPersist(
+				func(name string, data []byte, path string) error {
+					if name != wantName {
+						t.Errorf("unexpected name, want '%v' actual '%v'", wantName, name)
+					}
+					return nil
+				},
+			)
+		}
+	)
+	aMockGen, err := text_template.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Valid mock", func(t *testing.T) {
+		conf := Conf{Package: "mock", Path: t.TempDir(), TypeCheck: true}
+		aMock, err := New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = aMock.GenerateAs(tp, conf); err != nil {
+			t.Fatalf("unexpected error '%v'", err)
+		}
+		_, err = os.Stat(filepath.Join(conf.Path, "Reader"+FilenameExtenstion))
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("Valid mock in the interface package", func(t *testing.T) {
+		persistor := persist(t, "a__StoreMock.gen.go")
+		conf := Conf{Package: "amockgen", Name: "StoreMock",
+			File: "a__{{.Name}}.gen.go", Path: "testdata/amockgen", TypeCheck: true}
+		err := NewWith(aMockGen, persistor).GenerateSource("./testdata/amockgen",
+			"Store", conf)
+		if err != nil {
+			t.Fatalf("unexpected error '%v'", err)
+		}
+		if result := CheckCalls([]*core.Mock{persistor.Mock}); len(result) > 0 {
+			t.Error(result)
+		}
+	})
+
+	t.Run("Names shadowing types in the interface package",
+		func(t *testing.T) {
+			persistor := persist(t, "a__ShadowerMock.gen.go")
+			conf := Conf{Package: "amockgen", Name: "ShadowerMock",
+				File: "a__{{.Name}}.gen.go", Path: "testdata/amockgen",
+				TypeCheck: true}
+			err := NewWith(aMockGen, persistor).GenerateSource("./testdata/amockgen",
+				"Shadower", conf)
+			if err != nil {
+				t.Fatalf("unexpected error '%v'", err)
+			}
+			if result := CheckCalls([]*core.Mock{persistor.Mock}); len(result) > 0 {
+				t.Error(result)
+			}
+		})
+
+	t.Run("Mock redeclared in the target package", func(t *testing.T) {
+		persistor := mock.NewPersistor()
+		conf := Conf{Package: "amockgen", Name: "StoreMock",
+			Path: "testdata/amockgen", TypeCheck: true}
+		err := NewWith(aMockGen, persistor).GenerateSource("./testdata/amockgen",
+			"Store", conf)
+		var typeCheckErr *TypeCheckError
+		if !errors.As(err, &typeCheckErr) {
+			t.Fatalf("unexpected error '%v'", err)
+		}
+		want := filepath.Join(conf.Path, "StoreMock"+FilenameExtenstion)
+		if typeCheckErr.Filename() != want {
+			t.Errorf("unexpected filename '%v'", typeCheckErr.Filename())
+		}
+		for _, e := range typeCheckErr.Errors() {
+			if pos := e.Fset.Position(e.Pos); pos.Filename != want || pos.Line == 0 {
+				t.Errorf("unexpected position '%v'", pos)
+			}
+		}
+		if !strings.Contains(err.Error(), "StoreMock redeclared") {
+			t.Errorf("unexpected error '%v'", err)
+		}
+	})
+
+	t.Run("Implements source", func(t *testing.T) {
+		const v1Path = "github.com/ymz-ncnk/amock/testdata/amockgen/v1/mock"
+		iDesc := amockgen.MockImplDesc{
+			InterfaceType: "mock.Source",
+			Package:       "mock",
+			Name:          "ItemSource",
+			Imports: []amockgen.ImportDesc{
+				{Name: "mock", Alias: "mock", Path: v1Path},
+			},
+		}
+		for path, want := range map[string]string{
+			"github.com/ymz-ncnk/amock/testdata/mock": "import mock \"" + v1Path +
+				"\"\n\nfunc _() {\n\tvar _ mock.Source = ItemSource{}\n}\n",
+			v1Path: "\nfunc _() {\n\tvar _ Source = ItemSource{}\n}\n",
+		} {
+			iDesc.PackagePath = path
+			src := string(implementsSource(iDesc))
+			if want = "package mock\n\n" + want; src != want {
+				t.Errorf("unexpected source for %v, want '%v' actual '%v'", path, want,
+					src)
+			}
+		}
+	})
+
+	t.Run("Broken mock is not persisted", func(t *testing.T) {
+		brokenGen := mock.NewAMockGen().RegisterGenerate(
+			func(iDesc amockgen.MockImplDesc) ([]byte, error) {
+				return []byte(`package mock
+
+type Reader struct{}
+
+func (mock Reader) Read(p []byte) (int, error) {
+	return undefined, nil
+}
+`), nil
+			},
+		)
+		persistor := mock.NewPersistor()
+		conf := Conf{Package: "mock", Path: t.TempDir(), TypeCheck: true}
+		err := NewWith(brokenGen, persistor).GenerateAs(tp, conf)
+		var typeCheckErr *TypeCheckError
+		if !errors.As(err, &typeCheckErr) || len(typeCheckErr.Errors()) != 1 {
+			t.Fatalf("unexpected error '%v'", err)
+		}
+		e := typeCheckErr.Errors()[0]
+		if pos := e.Fset.Position(e.Pos); pos.Line != 6 || e.Msg !=
+			"undefined: undefined" {
+			t.Errorf("unexpected error '%v'", e)
+		}
+	})
+
+	t.Run("Mock doesn't implement the interface", func(t *testing.T) {
+		brokenGen := mock.NewAMockGen().RegisterGenerate(
+			func(iDesc amockgen.MockImplDesc) ([]byte, error) {
+				return []byte("package mock\n\ntype Reader struct{}\n"), nil
+			},
+		)
+		conf := Conf{Package: "mock", Path: t.TempDir(), TypeCheck: true}
+		err := NewWith(brokenGen, mock.NewPersistor()).GenerateAs(tp, conf)
+		if err == nil || !strings.Contains(err.Error(),
+			"Reader does not implement io.Reader (missing method Read)") {
+			t.Errorf("unexpected error '%v'", err)
+		}
+	})
+}
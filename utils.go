@@ -1,6 +1,10 @@
 package amock
 
-import "github.com/ymz-ncnk/amock/core"
+import (
+	"context"
+
+	"github.com/ymz-ncnk/amock/core"
+)
 
 // CheckCalls checks if all registered method calls were made for each mock.
 // If yes, it returns an empty result map. Otherwise, it returns a map where
@@ -16,3 +20,15 @@ func CheckCalls(mocks []*core.Mock) (result map[int][]core.MethodCallsInfo) {
 	}
 	return
 }
+
+// WaitAll blocks until all registered method calls of each mock have been
+// made and completed, see core.Mock.Wait. If ctx is done earlier, the
+// core.WaitTimeoutError of the first unfinished mock is returned.
+func WaitAll(ctx context.Context, mocks ...*core.Mock) error {
+	for i := 0; i < len(mocks); i++ {
+		if err := mocks[i].Wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
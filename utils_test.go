@@ -1,8 +1,10 @@
 package amock
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/ymz-ncnk/amock/core"
 	testdata_amockgen "github.com/ymz-ncnk/amock/testdata/amockgen"
@@ -32,6 +34,39 @@ func TestCheckCalls(t *testing.T) {
 	}
 }
 
+func TestWaitAll(t *testing.T) {
+	var (
+		reader1 = testdata_amockgen.NewReaderMock()
+		reader2 = testdata_amockgen.NewReaderMock()
+	)
+	reader1.RegisterRead(func(p []byte) (n int, err error) {
+		return
+	})
+	reader2.RegisterNRead(2, func(p []byte) (n int, err error) {
+		return
+	})
+	go func() {
+		reader1.Read(nil)
+		reader2.Read(nil)
+		reader2.Read(nil)
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := WaitAll(ctx, reader1.Mock, reader2.Mock); err != nil {
+		t.Fatal(err)
+	}
+
+	reader1.RegisterRead(func(p []byte) (n int, err error) {
+		return
+	})
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := WaitAll(ctx, reader1.Mock, reader2.Mock)
+	if _, ok := err.(*core.WaitTimeoutError); !ok {
+		t.Errorf("unexpected error '%v'", err)
+	}
+}
+
 func checkMethodCallsInfo(info core.MethodCallsInfo, expectedCalls,
 	actualCalls int) error {
 	if info.MockName != "ReaderMock" {